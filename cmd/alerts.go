@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/alert"
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/config"
+	"github.com/preflightsh/preflight/internal/netutil"
+)
+
+// alertWebhookEnv supplies the alert webhook when preflight.yml doesn't, so
+// CI schedules can keep the URL in a secret.
+const alertWebhookEnv = "PREFLIGHT_ALERT_WEBHOOK"
+
+// sendTransitionAlerts compares this scan with the project's previous one
+// and posts the checks that changed state (or are flapping) to the alert
+// webhook. Like --publish it is best-effort: failures go to stderr and
+// never change the exit code. The webhook may come from a committed
+// preflight.yml, so private and loopback addresses are refused. The state
+// is only saved once the alert is delivered, so a failed POST is retried
+// on the next run.
+func sendTransitionAlerts(cfg *config.PreflightConfig, projectDir string, results []checks.CheckResult) {
+	webhook := alertWebhook(cfg)
+	if webhook == "" {
+		return
+	}
+
+	path, err := alert.DefaultPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not locate alert state: %v\n", err)
+		return
+	}
+	key := projectKey(projectDir, cfg.ProjectName)
+	prev, err := alert.Load(path, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not read alert state: %v\n", err)
+		return
+	}
+
+	policy := alert.Policy{
+		Cooldown:      time.Duration(cfg.Alerts.CooldownMinutes) * time.Minute,
+		FlapThreshold: cfg.Alerts.FlapThreshold,
+		FlapWindow:    time.Duration(cfg.Alerts.FlapWindowHours) * time.Hour,
+	}
+	alerts, next := alert.Evaluate(prev, results, time.Now().UTC(), policy)
+
+	if len(alerts) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		payload := alert.NewPayload(cfg.ProjectName, cfg.URLs.Production, alerts)
+		if err := alert.Send(ctx, netutil.SafeHTTPClient(15*time.Second), webhook, payload); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not send alert: %v\n", err)
+			return
+		}
+	}
+	if err := alert.Save(path, key, next); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not save alert state: %v\n", err)
+	}
+}
+
+// alertWebhook is the configured alert webhook, from preflight.yml or
+// PREFLIGHT_ALERT_WEBHOOK, or "" when alerts are off.
+func alertWebhook(cfg *config.PreflightConfig) string {
+	if cfg.Alerts.Webhook != "" {
+		return cfg.Alerts.Webhook
+	}
+	return os.Getenv(alertWebhookEnv)
+}
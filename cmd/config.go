@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/preflightsh/preflight/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configDocsOutput string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the preflight.yml configuration format",
+}
+
+var configDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Print a markdown reference of every preflight.yml key",
+	Long: `Print a markdown reference of every preflight.yml key: its type, its
+default, the checks that read it and what it does.
+
+The reference is generated from the config definitions, so it always
+matches this version of preflight.
+
+Example:
+  preflight config docs
+  preflight config docs -o docs/config.md`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDocs,
+}
+
+func init() {
+	configDocsCmd.Flags().StringVarP(&configDocsOutput, "output", "o", "", "Write the reference to a file instead of stdout")
+	configCmd.AddCommand(configDocsCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigDocs(cmd *cobra.Command, args []string) error {
+	if configDocsOutput == "" {
+		return writeConfigDocs(os.Stdout, config.Reference())
+	}
+	f, err := os.Create(configDocsOutput)
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to create %s: %w", configDocsOutput, err)}
+	}
+	if err := writeConfigDocs(f, config.Reference()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeConfigDocs renders the reference as one table per section: the
+// top-level keys, then each top-level block, with each check's settings
+// under checks.<name>. Sections keep the order they're declared in.
+func writeConfigDocs(w io.Writer, docs []config.KeyDoc) error {
+	var order []string
+	rows := make(map[string][]string)
+	for _, d := range docs {
+		s := configDocSection(d.Key)
+		if _, seen := rows[s]; !seen {
+			order = append(order, s)
+		}
+
+		def := "—"
+		if d.Default != "" {
+			def = "`" + d.Default + "`"
+		}
+		usedBy := "—"
+		if len(d.Checks) > 0 {
+			usedBy = "`" + strings.Join(d.Checks, "`, `") + "`"
+		}
+		rows[s] = append(rows[s], fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", d.Key, d.Type, def, usedBy, markdownCell(d.Description)))
+	}
+	// Top-level keys first, wherever they're declared.
+	sort.SliceStable(order, func(i, j int) bool { return order[i] == "" && order[j] != "" })
+
+	var b strings.Builder
+	b.WriteString("# preflight.yml reference\n\n")
+	b.WriteString("Generated by `preflight config docs`; don't edit by hand.\n")
+	for _, s := range order {
+		if s == "" {
+			b.WriteString("\n## Top level\n\n")
+		} else {
+			fmt.Fprintf(&b, "\n## `%s`\n\n", s)
+		}
+		b.WriteString("| Key | Type | Default | Used by | Description |\n")
+		b.WriteString("|-----|------|---------|---------|-------------|\n")
+		for _, row := range rows[s] {
+			b.WriteString(row)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// configDocSection groups a key under its top-level block, or under
+// checks.<name> for per-check settings. Top-level scalars have no section.
+func configDocSection(key string) string {
+	parts := strings.Split(key, ".")
+	switch {
+	case len(parts) == 1:
+		return ""
+	case parts[0] == "checks" && len(parts) > 2:
+		return strings.TrimSuffix(parts[0]+"."+parts[1], "[]")
+	default:
+		return strings.TrimSuffix(parts[0], "[]")
+	}
+}
+
+// markdownCell escapes text for a table cell, where | ends the cell,
+// <...> would be read as HTML and * as emphasis.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "<", `\<`, ">", `\>`, "*", `\*`).Replace(s)
+}
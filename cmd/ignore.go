@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/preflightsh/preflight/internal/audit"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -101,6 +102,7 @@ func runIgnore(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Added '%s' to ignore list\n", checkID)
+	recordAudit(audit.ActionIgnore, cwd, configProjectName(cfg), "ignored check "+checkID)
 	return nil
 }
 
@@ -148,6 +150,7 @@ func addSecretsAllowlistEntry(configPath string, cfg map[string]interface{}, pat
 	}
 
 	fmt.Printf("Added '%s' to secrets allowlist. Consider adding a fingerprint to re-alert on key rotation (see README).\n", path)
+	recordAudit(audit.ActionIgnore, filepath.Dir(configPath), configProjectName(cfg), "allowlisted "+path+" in the secrets scan")
 	return nil
 }
 
@@ -235,9 +238,17 @@ func runUnignore(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Removed '%s' from ignore list\n", checkID)
+	recordAudit(audit.ActionUnignore, cwd, configProjectName(cfg), "unignored check "+checkID)
 	return nil
 }
 
+// configProjectName returns projectName from a preflight.yml parsed as a
+// generic map.
+func configProjectName(cfg map[string]interface{}) string {
+	name, _ := cfg["projectName"].(string)
+	return name
+}
+
 // Helper to list available check IDs
 var listChecksCmd = &cobra.Command{
 	Use:   "checks",
@@ -261,23 +272,37 @@ var listChecksCmd = &cobra.Command{
 		fmt.Println("  - ssl")
 		fmt.Println("  - www_redirect")
 		fmt.Println("  - email_auth (opt-in)")
+		fmt.Println("  - ipv6 (opt-in)")
+		fmt.Println("  - multi_region (opt-in)")
 		fmt.Println("  - secrets")
 		fmt.Println()
 
 		fmt.Println("Environment & Health:")
 		fmt.Println("  - envParity")
+		fmt.Println("  - platform_env (with envParity)")
+		fmt.Println("  - secrets_manager")
 		fmt.Println("  - healthEndpoint")
 		fmt.Println()
 
 		fmt.Println("Code Quality & Performance:")
 		fmt.Println("  - vulnerability")
 		fmt.Println("  - debug_statements")
+		fmt.Println("  - deprecated_services")
+		fmt.Println("  - dead_code (opt-in)")
+		fmt.Println("  - build_freshness (opt-in)")
 		fmt.Println("  - error_pages")
 		fmt.Println("  - image_optimization")
 		fmt.Println()
 
 		fmt.Println("Legal & Compliance:")
 		fmt.Println("  - legal_pages")
+		fmt.Println("  - legal_placeholders (opt-in)")
+		fmt.Println("  - legal_freshness (opt-in)")
+		fmt.Println("  - consent_mode (opt-in, or compliance region eu/uk)")
+		fmt.Println("  - consent_banner (compliance region eu/uk)")
+		fmt.Println("  - do_not_sell (compliance region us-ca)")
+		fmt.Println("  - impressum (compliance region dach)")
+		fmt.Println("  - age_gate (compliance vertical)")
 		fmt.Println()
 
 		fmt.Println("Web Standard Files:")
@@ -290,6 +315,10 @@ var listChecksCmd = &cobra.Command{
 		fmt.Println("  - license (opt-in)")
 		fmt.Println()
 
+		fmt.Println("Polish:")
+		fmt.Println("  - email_obfuscation (opt-in)")
+		fmt.Println()
+
 		fmt.Println("=== Services (with validation checks) ===")
 		fmt.Println()
 		fmt.Println("These services have checks that verify proper integration:")
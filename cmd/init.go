@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/preflightsh/preflight/internal/audit"
 	"github.com/preflightsh/preflight/internal/config"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -120,6 +121,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Ask about humans.txt
 	checkHumansTxt := promptYesNo(reader, "Got a humans.txt crediting the team?", false)
 
+	// Ask which legal regimes apply; each region turns on its own legal checks
+	regions := parseComplianceRegions(promptOptional(reader, "Legal regions served (comma-separated: "+strings.Join(config.ComplianceRegions, ", ")+"; blank for none)"))
+
 	// Handle IndexNow - user already confirmed/declined in services section
 	var indexNowKey string
 	indexNowConfirmed := confirmedServices["indexnow"].Declared
@@ -158,6 +162,12 @@ func runInit(cmd *cobra.Command, args []string) error {
 		delete(confirmedServices, "indexnow")
 	}
 
+	// Offer to write security.txt, humans.txt and llms.txt; a generated
+	// humans.txt turns its check on.
+	if generateWellKnownFiles(reader, cwd, projectName, stack, productionURL) {
+		checkHumansTxt = true
+	}
+
 	// Build full services map with all services (declared: true or false)
 	allServices := make(map[string]config.ServiceConfig)
 	for _, svc := range config.AllServices {
@@ -181,6 +191,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 		},
 		Services: allServices,
 		Checks:   buildDefaultChecks(cwd, stack, allServices, productionURL, hasLicense, hasAds, indexNowKey, checkEmailAuth, checkHumansTxt),
+		Compliance: config.ComplianceConfig{
+			Regions: regions,
+		},
 	}
 
 	// Write config file
@@ -191,6 +204,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 	fmt.Printf("✅ Created %s\n", configPath)
+	recordAudit(audit.ActionInit, cwd, projectName, fmt.Sprintf("created %s (stack: %s)", configPath, stack))
 
 	// Check and update .gitignore
 	gitignorePath := filepath.Join(cwd, ".gitignore")
@@ -354,6 +368,34 @@ func promptYesNo(reader *bufio.Reader, prompt string, defaultYes bool) bool {
 	return input == "y" || input == "yes"
 }
 
+// parseComplianceRegions splits a comma-separated region answer, normalized
+// the way preflight.yml is (so "de" means dach), dropping duplicates and
+// reporting anything that isn't a known compliance region.
+func parseComplianceRegions(input string) []string {
+	var regions []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(input, ",") {
+		region := config.NormalizeRegion(part)
+		if region == "" || seen[region] {
+			continue
+		}
+		known := false
+		for _, valid := range config.ComplianceRegions {
+			if region == valid {
+				known = true
+				break
+			}
+		}
+		if !known {
+			fmt.Printf("  ⚠️  Unknown region %q ignored\n", strings.TrimSpace(part))
+			continue
+		}
+		seen[region] = true
+		regions = append(regions, region)
+	}
+	return regions
+}
+
 func getDefaultProjectName(cwd string) string {
 	base := filepath.Base(cwd)
 	if base == "" || base == "." || base == string(filepath.Separator) {
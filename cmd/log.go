@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/preflightsh/preflight/internal/audit"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logAll    bool
+	logAction string
+	logLimit  int
+	logFormat string
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the local audit log of scans, inits, ignores and sign-offs",
+	Long: `Show the append-only audit log kept in ~/.preflight/audit.log.
+
+Every scan, init, ignore/unignore and signed report is recorded with the
+time, the git user (user.name and user.email), the project and what changed.
+Each entry includes a hash of the one before it, so an edited or deleted
+entry is reported as a break in the chain. Removing the newest entries leaves
+no break, so the log alone can't show that it was cut short.
+
+By default only entries for the project in the current directory are shown.
+
+Example:
+  preflight log
+  preflight log --action ignore
+  preflight log --all --format json`,
+	Args: cobra.NoArgs,
+	RunE: runLog,
+}
+
+func init() {
+	logCmd.Flags().BoolVar(&logAll, "all", false, "Show entries for every project, not just the current directory")
+	logCmd.Flags().StringVar(&logAction, "action", "", "Only show one action: scan, init, ignore, unignore or sign-off")
+	logCmd.Flags().IntVar(&logLimit, "limit", 50, "Maximum number of entries to show (most recent first, 0 for all)")
+	logCmd.Flags().StringVar(&logFormat, "format", "human", "Output format: human or json")
+	rootCmd.AddCommand(logCmd)
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	if logFormat != "human" && logFormat != "json" {
+		return &ExitError{Code: 2, Err: fmt.Errorf("invalid --format %q (want human or json)", logFormat)}
+	}
+
+	path, err := audit.DefaultPath()
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+	entries, broken, err := audit.Read(path)
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to read audit log: %w", err)}
+	}
+
+	cwd := ""
+	if !logAll {
+		if cwd, err = filepath.Abs("."); err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+	}
+
+	// Most recent first.
+	var shown []audit.Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if cwd != "" && e.Dir != cwd {
+			continue
+		}
+		if logAction != "" && e.Action != logAction {
+			continue
+		}
+		shown = append(shown, e)
+		if logLimit > 0 && len(shown) == logLimit {
+			break
+		}
+	}
+
+	if len(broken) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Audit log chain is broken at line(s) %v of %s: entries were edited or removed\n", broken, path)
+	}
+
+	if logFormat == "json" {
+		if shown == nil {
+			shown = []audit.Entry{}
+		}
+		return printJSON(map[string]any{"entries": shown, "intact": len(broken) == 0})
+	}
+
+	if len(shown) == 0 {
+		if cwd != "" {
+			fmt.Println("No audit entries for this project. Use --all to see every project.")
+		} else {
+			fmt.Println("The audit log is empty.")
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "WHEN\tACTION\tUSER\tPROJECT\tSUMMARY")
+	for _, e := range shown {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04"), e.Action, auditUser(e), truncate(e.Project, 28), e.Summary)
+	}
+	return tw.Flush()
+}
+
+func auditUser(e audit.Entry) string {
+	switch {
+	case e.User != "" && e.Email != "":
+		return fmt.Sprintf("%s <%s>", e.User, e.Email)
+	case e.User != "":
+		return e.User
+	case e.Email != "":
+		return e.Email
+	default:
+		return "-"
+	}
+}
+
+// recordAudit appends an entry to the audit log. Best-effort: a failure
+// is reported on stderr but never fails the command being audited.
+func recordAudit(action, dir, project, summary string) {
+	path, err := audit.DefaultPath()
+	if err == nil {
+		err = audit.Append(path, audit.NewEntry(action, dir, project, summary))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not write audit log: %v\n", err)
+	}
+}
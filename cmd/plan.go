@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+// scanPlan is what `preflight scan --plan` reports: the checks a scan would
+// run, what each one would look at, and the preflight.yml settings each one
+// would use.
+type scanPlan struct {
+	Project   string          `json:"project"`
+	Dir       string          `json:"dir"`
+	UserAgent string          `json:"user_agent,omitempty"`
+	Settings  []planSetting   `json:"settings"`
+	Checks    []plannedCheck  `json:"checks"`
+	Excluded  []excludedCheck `json:"excluded,omitempty"`
+}
+
+type planSetting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type plannedCheck struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Targets are the URLs, hosts and files the check would read. Checks
+	// that search the whole project have none.
+	Targets  []string      `json:"targets,omitempty"`
+	Settings []planSetting `json:"settings,omitempty"`
+}
+
+// excludedCheck is a check the config enables that this run won't, and why.
+type excludedCheck struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// buildScanPlan describes a scan of dir without running it. all is every
+// check the config enables; run is what's left after the ignore list and
+// --only / --skip. Secret values are redacted (see config.Settings).
+func buildScanPlan(cfg *config.PreflightConfig, dir string, all, run []checks.Check) scanPlan {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	plan := scanPlan{
+		Project:   cfg.ProjectName,
+		Dir:       dir,
+		UserAgent: scanUserAgent(cfg),
+		Settings:  []planSetting{},
+		Checks:    []plannedCheck{},
+	}
+
+	// Settings that no check claims (URLs, limits, alerts, ...) apply to
+	// the scan as a whole.
+	settings := config.Settings(cfg)
+	for _, s := range settings {
+		if len(s.Checks) == 0 && !strings.HasPrefix(s.Key, "services.") {
+			plan.Settings = append(plan.Settings, planSetting{s.Key, s.Value})
+		}
+	}
+
+	running := make(map[string]bool, len(run))
+	for _, c := range run {
+		running[c.ID()] = true
+		pc := plannedCheck{ID: c.ID(), Title: c.Title(), Targets: checkTargets(cfg, dir, c.ID())}
+		for _, s := range settings {
+			if checkReadsSetting(c.ID(), s) {
+				pc.Settings = append(pc.Settings, planSetting{s.Key, s.Value})
+			}
+		}
+		plan.Checks = append(plan.Checks, pc)
+	}
+
+	ignored := make(map[string]bool, len(cfg.Ignore))
+	for _, id := range cfg.Ignore {
+		ignored[id] = true
+	}
+	for _, c := range all {
+		if running[c.ID()] {
+			continue
+		}
+		reason := "excluded by --only / --skip"
+		if ignored[c.ID()] {
+			reason = "ignored in preflight.yml"
+		}
+		plan.Excluded = append(plan.Excluded, excludedCheck{ID: c.ID(), Reason: reason})
+	}
+	return plan
+}
+
+// checkTargets lists what check id would read: the URLs it requests (from
+// urls.production and urls.staging), the hosts it resolves and the files
+// it opens, from its config or the defaults. Secret values are left out.
+func checkTargets(cfg *config.PreflightConfig, dir, id string) []string {
+	prod := strings.TrimSuffix(cfg.URLs.Production, "/")
+	staging := strings.TrimSuffix(cfg.URLs.Staging, "/")
+	// Most live checks prefer one environment and fall back to the other.
+	stagingFirst, prodFirst := staging, prod
+	if stagingFirst == "" {
+		stagingFirst = prod
+	}
+	if prodFirst == "" {
+		prodFirst = staging
+	}
+	var homepages []string
+	for _, u := range []string{prod, staging} {
+		if u != "" {
+			homepages = append(homepages, u+" (homepage)")
+		}
+	}
+	under := func(base string, paths ...string) []string {
+		if base == "" {
+			return nil
+		}
+		out := make([]string, len(paths))
+		for i, p := range paths {
+			out[i] = base + p
+		}
+		return out
+	}
+	prodHost := ""
+	if u, err := url.Parse(prod); err == nil {
+		prodHost = u.Hostname()
+	}
+	var targets []string
+
+	switch id {
+	case "envParity":
+		if e := cfg.Checks.EnvParity; e != nil {
+			targets = append(targets, e.EnvFile, e.ExampleFile)
+		}
+	case "platform_env":
+		if e := cfg.Checks.EnvParity; e != nil {
+			targets = append(targets, e.ExampleFile)
+			if e.Platform != "" {
+				targets = append(targets, e.Platform+" API")
+			}
+		}
+	case "seoMeta", "canonical", "ogTwitter", "viewport", "lang", "structured_data", "favicon":
+		if layout := planLayout(cfg, dir); layout != "" {
+			targets = append(targets, layout)
+		}
+		targets = append(targets, homepages...)
+	case "consent_banner", "do_not_sell", "impressum", "google_analytics":
+		targets = homepages
+	case "age_gate", "consent_mode", "email_obfuscation":
+		if prod != "" {
+			targets = []string{prod + " (homepage)"}
+		}
+	case "securityHeaders":
+		targets = under(prod, "/")
+		targets = append(targets, under(staging, "/")...)
+	case "ssl", "www_redirect":
+		if prodHost != "" {
+			targets = []string{prodHost}
+		}
+	case "email_auth":
+		if prodHost != "" {
+			targets = []string{prodHost + " (SPF, DMARC records)"}
+		}
+	case "ipv6":
+		if prodHost != "" {
+			targets = []string{prodHost + " (AAAA records)", prod, "check-host.net (when this machine has no IPv6 route)"}
+		}
+	case "multi_region":
+		if mr := cfg.Checks.MultiRegion; mr != nil && len(mr.Probes) > 0 {
+			for _, p := range mr.Probes {
+				targets = append(targets, p.URL)
+			}
+		} else {
+			targets = append(targets, "check-host.net")
+		}
+		if prod != "" {
+			targets = append(targets, prod)
+		}
+	case "healthEndpoint":
+		path := "/health"
+		if h := cfg.Checks.HealthEndpoint; h != nil && h.Path != "" {
+			path = h.Path
+		}
+		targets = under(stagingFirst, path)
+	case "error_pages":
+		targets = under(stagingFirst, "/preflight-404-probe-please-do-not-exist")
+	case "legal_pages":
+		targets = under(stagingFirst, "/privacy", "/terms")
+	case "legal_freshness":
+		targets = under(prodFirst, "/privacy", "/terms")
+	case "robotsTxt":
+		targets = under(stagingFirst, "/robots.txt")
+	case "sitemap":
+		targets = under(stagingFirst, "/sitemap.xml")
+	case "llmsTxt":
+		targets = under(stagingFirst, "/llms.txt")
+	case "indexNow":
+		targets = under(stagingFirst, "/<key>.txt")
+	case "stripe":
+		targets = []string{"checks.stripeWebhook.url (redacted)"}
+	}
+	return targets
+}
+
+// planLayout is the template the SEO checks read: checks.seoMeta.mainLayout
+// or the auto-detected layout.
+func planLayout(cfg *config.PreflightConfig, dir string) string {
+	if s := cfg.Checks.SEOMeta; s != nil && s.MainLayout != "" {
+		return s.MainLayout
+	}
+	if layout := detectLayout(dir, cfg.Stack); layout != "" {
+		return layout + " (auto-detected)"
+	}
+	return ""
+}
+
+// checkReadsSetting reports whether s configures check id: either its
+// section names the check, or it's the check's service declaration.
+func checkReadsSetting(id string, s config.Setting) bool {
+	for _, c := range s.Checks {
+		if c == id {
+			return true
+		}
+	}
+	return strings.HasPrefix(s.Key, "services."+id+".")
+}
+
+// printScanPlan prints the plan as JSON or as a readable list.
+func printScanPlan(cfg *config.PreflightConfig, dir string, all, run []checks.Check) error {
+	plan := buildScanPlan(cfg, dir, all, run)
+	if formatFlag == "json" {
+		return printJSON(plan)
+	}
+
+	name := plan.Project
+	if name == "" {
+		name = filepath.Base(plan.Dir)
+	}
+	fmt.Printf("Scan plan for %s (nothing has been run)\n\n", name)
+	fmt.Printf("Directory:  %s\n", plan.Dir)
+	if plan.UserAgent != "" {
+		fmt.Printf("User-Agent: %s\n", plan.UserAgent)
+	} else {
+		fmt.Println("User-Agent: Go default (http.identify: false)")
+	}
+	if len(plan.Settings) > 0 {
+		fmt.Println("\nScan settings:")
+		for _, s := range plan.Settings {
+			fmt.Printf("  %s: %s\n", s.Key, s.Value)
+		}
+	}
+
+	fmt.Printf("\n%d checks would run:\n", len(plan.Checks))
+	for _, c := range plan.Checks {
+		fmt.Printf("  %-22s %s\n", c.ID, c.Title)
+		for _, t := range c.Targets {
+			fmt.Printf("  %-22s   → %s\n", "", t)
+		}
+		for _, s := range c.Settings {
+			fmt.Printf("  %-22s   %s: %s\n", "", s.Key, s.Value)
+		}
+	}
+
+	if len(plan.Excluded) > 0 {
+		fmt.Printf("\n%d enabled checks would be skipped:\n", len(plan.Excluded))
+		for _, e := range plan.Excluded {
+			fmt.Printf("  %-22s %s\n", e.ID, e.Reason)
+		}
+	}
+	return nil
+}
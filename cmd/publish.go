@@ -85,9 +85,10 @@ func redactChecks(results []checks.CheckResult) []dashboard.PublishCheck {
 }
 
 // redactedConfigYAML re-marshals the config with secret-bearing fields cleared:
-// the IndexNow key, the Stripe webhook URL, and the secrets allowlist (which
-// contains file paths and fingerprints). Service declarations, stack, and
-// public URLs are kept because they give the dashboard's AI useful context.
+// the IndexNow key, the Stripe webhook URL, the alert webhook URL, and the
+// secrets allowlist (which contains file paths and fingerprints). Service
+// declarations, stack, and public URLs are kept because they give the
+// dashboard's AI useful context.
 func redactedConfigYAML(cfg *config.PreflightConfig) string {
 	c := *cfg
 	if c.Checks.IndexNow != nil {
@@ -100,6 +101,7 @@ func redactedConfigYAML(cfg *config.PreflightConfig) string {
 		tmp.URL = ""
 		c.Checks.StripeWebhook = &tmp
 	}
+	c.Alerts.Webhook = ""
 	if c.Checks.Secrets != nil {
 		tmp := *c.Checks.Secrets
 		tmp.Allowlist = nil
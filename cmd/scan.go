@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/preflightsh/preflight/internal/attest"
+	"github.com/preflightsh/preflight/internal/audit"
 	"github.com/preflightsh/preflight/internal/checks"
 	"github.com/preflightsh/preflight/internal/config"
 	"github.com/preflightsh/preflight/internal/netutil"
@@ -25,6 +29,9 @@ var (
 	publishFlag bool
 	onlyFlag    []string
 	skipFlag    []string
+	signFlag    bool
+	signKeyFlag string
+	planFlag    bool
 )
 
 var scanCmd = &cobra.Command{
@@ -44,6 +51,9 @@ func init() {
 	scanCmd.Flags().BoolVar(&publishFlag, "publish", false, "Publish results to your Preflight dashboard (requires 'preflight auth login')")
 	scanCmd.Flags().StringSliceVar(&onlyFlag, "only", nil, "Run only these check/service IDs (comma-separated; see 'preflight checks')")
 	scanCmd.Flags().StringSliceVar(&skipFlag, "skip", nil, "Skip these check/service IDs for this run (comma-separated)")
+	scanCmd.Flags().BoolVar(&signFlag, "sign", false, "Sign the JSON report with your local key (verify with 'preflight verify')")
+	scanCmd.Flags().StringVar(&signKeyFlag, "sign-key", "", "Signing key to use with --sign (default ~/.preflight/signing_key, created on first use)")
+	scanCmd.Flags().BoolVar(&planFlag, "plan", false, "List the checks that would run, their targets and settings, without running anything")
 	_ = scanCmd.RegisterFlagCompletionFunc("only", completeCheckIDs)
 	_ = scanCmd.RegisterFlagCompletionFunc("skip", completeCheckIDs)
 }
@@ -102,7 +112,7 @@ func filterChecksByFlags(enabled []checks.Check, only, skip []string) ([]checks.
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
-	if !ciMode {
+	if !ciMode && !planFlag {
 		CheckForUpdates()
 	}
 
@@ -126,6 +136,34 @@ func runScan(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if planFlag && (signFlag || publishFlag) {
+		return &ExitError{Code: 2, Err: fmt.Errorf("--plan can't be combined with --sign or --publish (nothing is run)")}
+	}
+
+	// Load the signing key up front so a bad key fails before the scan
+	// rather than after it.
+	var signKey ed25519.PrivateKey
+	if signFlag {
+		if formatFlag != "json" {
+			return &ExitError{Code: 2, Err: fmt.Errorf("--sign requires --format json")}
+		}
+		keyPath := signKeyFlag
+		if keyPath == "" {
+			var err error
+			if keyPath, err = attest.DefaultKeyPath(); err != nil {
+				return &ExitError{Code: 2, Err: fmt.Errorf("failed to locate signing key: %w", err)}
+			}
+		}
+		key, created, err := attest.LoadOrCreateKey(keyPath)
+		if err != nil {
+			return &ExitError{Code: 2, Err: err}
+		}
+		if created {
+			fmt.Fprintf(os.Stderr, "Created signing key %s (public key: %s.pub)\n", keyPath, keyPath)
+		}
+		signKey = key
+	}
+
 	// Load config
 	cfg, err := config.Load(projectDir)
 	if err != nil {
@@ -136,18 +174,54 @@ func runScan(cmd *cobra.Command, args []string) error {
 		return &ExitError{Code: 2, Err: fmt.Errorf("%s", msg)}
 	}
 
+	// Build list of enabled checks
+	allChecks := buildEnabledChecks(cfg, projectDir)
+	enabledChecks := allChecks
+
+	// Filter out ignored checks
+	if len(cfg.Ignore) > 0 {
+		ignoreMap := make(map[string]bool)
+		for _, id := range cfg.Ignore {
+			ignoreMap[id] = true
+		}
+		var filtered []checks.Check
+		for _, check := range enabledChecks {
+			if !ignoreMap[check.ID()] {
+				filtered = append(filtered, check)
+			}
+		}
+		enabledChecks = filtered
+	}
+
+	// One-off narrowing via --only / --skip.
+	enabledChecks, err = filterChecksByFlags(enabledChecks, onlyFlag, skipFlag)
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+
+	// --plan stops here, before any request is made or check is run.
+	if planFlag {
+		return printScanPlan(cfg, projectDir, allChecks, enabledChecks)
+	}
+
 	// Create HTTP client with timeout. SafeHTTPClient refuses to dial
 	// private/loopback/metadata IPs so a hostile preflight.yml cannot
 	// coerce checks into probing internal services. We fall back to a
 	// plain client when the user explicitly configured a local dev URL
 	// (localhost, *.local, *.test, *.ddev.site etc.) — that's a
 	// trusted-config workflow, not the hostile-repo threat model.
+	//
+	// Every client is wrapped by netutil.Polite: requests carry the
+	// preflight User-Agent (unless http.identify is false) and back off
+	// when a site answers 429 with Retry-After.
+	ua := scanUserAgent(cfg)
 	var httpClient *http.Client
 	if checks.IsLocalURL(cfg.URLs.Production) || checks.IsLocalURL(cfg.URLs.Staging) {
 		httpClient = &http.Client{Timeout: 2 * time.Second}
 	} else {
 		httpClient = netutil.SafeHTTPClient(2 * time.Second)
 	}
+	httpClient = netutil.Polite(httpClient, ua)
 
 	// Spinner gives the user something to watch while checks run. Off in
 	// CI and JSON modes (which expect quiet/structured output) and on
@@ -166,9 +240,24 @@ func runScan(cmd *cobra.Command, args []string) error {
 	// the context, which propagates to every in-flight HTTP request via
 	// http.NewRequestWithContext and lets checks return promptly instead
 	// of leaving the process hung on a long timeout.
-	scanCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	sigCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stopSignals()
 
+	// limits.timeoutSeconds puts a deadline on the whole scan. Checks
+	// still running at the deadline are abandoned and listed as not run,
+	// and the file and concurrency caps are shared by every check.
+	scanCtx := sigCtx
+	if cfg.Limits.TimeoutSeconds > 0 {
+		var cancelDeadline context.CancelFunc
+		scanCtx, cancelDeadline = context.WithTimeout(sigCtx, time.Duration(cfg.Limits.TimeoutSeconds)*time.Second)
+		defer cancelDeadline()
+	}
+	checks.SetBudget(checks.Budget{
+		Ctx:            scanCtx,
+		MaxFiles:       cfg.Limits.MaxFiles,
+		MaxConcurrency: cfg.Limits.MaxConcurrency,
+	})
+
 	// Create check context. Pre-fetch the homepage once so checks that
 	// need to scan rendered HTML (OG/Twitter and favicon detection for
 	// CMS-driven sites) can share a single request.
@@ -178,6 +267,11 @@ func runScan(cmd *cobra.Command, args []string) error {
 		Config:  cfg,
 		Client:  httpClient,
 		Verbose: verboseFlag,
+
+		UserAgent: ua,
+	}
+	if endpoint := netutil.DoHEndpoint(cfg.DNS.Resolver); endpoint != "" {
+		ctx.Resolver = &netutil.DoHResolver{URL: endpoint, Client: netutil.Polite(netutil.SafeHTTPClient(10*time.Second), ua)}
 	}
 	// Fetch staging and production homepage HTML in parallel. Staging
 	// uses the chosen httpClient (which is the relaxed client when
@@ -191,16 +285,20 @@ func runScan(cmd *cobra.Command, args []string) error {
 		var wg sync.WaitGroup
 		if cfg.URLs.Staging != "" {
 			wg.Add(1)
+			release := checks.AcquireWorker()
 			go func() {
 				defer wg.Done()
+				defer release()
 				ctx.PageHTMLStaging = checks.FetchPageHTML(scanCtx, httpClient, cfg.URLs.Staging)
 			}()
 		}
 		if cfg.URLs.Production != "" {
 			wg.Add(1)
+			release := checks.AcquireWorker()
 			go func() {
 				defer wg.Done()
-				prodClient := netutil.SafeHTTPClient(2 * time.Second)
+				defer release()
+				prodClient := netutil.Polite(netutil.SafeHTTPClient(2*time.Second), ua)
 				if checks.IsLocalURL(cfg.URLs.Production) {
 					prodClient = httpClient
 				}
@@ -217,42 +315,34 @@ func runScan(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Build list of enabled checks
-	enabledChecks := buildEnabledChecks(cfg, projectDir)
-
-	// Filter out ignored checks
-	if len(cfg.Ignore) > 0 {
-		ignoreMap := make(map[string]bool)
-		for _, id := range cfg.Ignore {
-			ignoreMap[id] = true
-		}
-		var filtered []checks.Check
-		for _, check := range enabledChecks {
-			if !ignoreMap[check.ID()] {
-				filtered = append(filtered, check)
-			}
-		}
-		enabledChecks = filtered
-	}
-
-	// One-off narrowing via --only / --skip.
-	enabledChecks, err = filterChecksByFlags(enabledChecks, onlyFlag, skipFlag)
-	if err != nil {
-		return &ExitError{Code: 2, Err: err}
-	}
-
 	// Run all checks
 	var results []checks.CheckResult
+	var notRun []string
 	for i, check := range enabledChecks {
 		// Honor Ctrl-C / SIGTERM between checks so a long scan can be
 		// stopped cleanly instead of being killed mid-request.
-		if scanCtx.Err() != nil {
+		if sigCtx.Err() != nil {
 			spinner.Stop()
 			fmt.Fprintln(os.Stderr, "\nScan cancelled.")
 			return &ExitError{Code: 130}
 		}
+		if scanCtx.Err() != nil {
+			notRun = checkIDs(enabledChecks[i:])
+			break
+		}
 		spinner.Update(fmt.Sprintf("Running %s (%d/%d)", check.Title(), i+1, len(enabledChecks)))
-		result, err := check.Run(ctx)
+		result, finished, err := runCheckBefore(scanCtx, check, ctx)
+		if sigCtx.Err() != nil {
+			spinner.Stop()
+			fmt.Fprintln(os.Stderr, "\nScan cancelled.")
+			return &ExitError{Code: 130}
+		}
+		if !finished || scanCtx.Err() != nil {
+			// The deadline cut this check short, so whatever it got to
+			// (usually a timeout) says nothing about the project.
+			notRun = checkIDs(enabledChecks[i:])
+			break
+		}
 		if err != nil {
 			// Convert error to failed check result
 			result = checks.CheckResult{
@@ -267,20 +357,49 @@ func runScan(cmd *cobra.Command, args []string) error {
 	}
 	spinner.Stop()
 
+	var incomplete []string
+	if len(notRun) > 0 {
+		incomplete = append(incomplete, fmt.Sprintf("scan deadline (%ds) reached; not run: %s", cfg.Limits.TimeoutSeconds, strings.Join(notRun, ", ")))
+	}
+	if checks.FileLimitReached() {
+		incomplete = append(incomplete, fmt.Sprintf("file limit (%d) reached; file-based checks only searched part of the project", cfg.Limits.MaxFiles))
+	}
+
 	// Output results
 	var outputter output.Outputter
 	if formatFlag == "json" {
-		outputter = output.JSONOutputter{}
+		outputter = output.JSONOutputter{SignKey: signKey, Incomplete: incomplete}
 	} else {
-		outputter = output.HumanOutputter{Verbose: verboseFlag}
+		outputter = output.HumanOutputter{Verbose: verboseFlag, Incomplete: incomplete}
 	}
 
-	outputter.Output(cfg.ProjectName, results)
+	if err := outputter.Output(cfg.ProjectName, results); err != nil {
+		// No report (or, with --sign, no signed report) was written, so
+		// this must not pass as a successful scan or a sign-off.
+		recordAudit(audit.ActionScan, projectDir, cfg.ProjectName, fmt.Sprintf("report output failed (exit 2): %v", err))
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to write report: %w", err)}
+	}
 
-	// Publish to the dashboard if requested. Best-effort: it never changes the
-	// scan's exit code and prints to stderr so JSON output stays clean.
-	if publishFlag {
-		_ = publishScanResults(cfg, projectDir, results)
+	if len(incomplete) > 0 {
+		// Partial results aren't the project's state: a check that only
+		// searched part of the tree can pass falsely, which the dashboard
+		// would show as clean and alerts would report as a recovery.
+		if publishFlag {
+			fmt.Fprintln(os.Stderr, "\nNot publishing: the scan is incomplete.")
+		}
+		if alertWebhook(cfg) != "" {
+			fmt.Fprintln(os.Stderr, "\nNot sending alerts: the scan is incomplete.")
+		}
+	} else {
+		// Publish to the dashboard if requested. Best-effort: it never changes
+		// the scan's exit code and prints to stderr so JSON output stays clean.
+		if publishFlag {
+			_ = publishScanResults(cfg, projectDir, results)
+		}
+
+		// Alert on checks that changed state since the last run, when an
+		// alert webhook is configured.
+		sendTransitionAlerts(cfg, projectDir, results)
 	}
 
 	// Show star message on first scan (only in human format, not JSON)
@@ -290,8 +409,22 @@ func runScan(cmd *cobra.Command, args []string) error {
 		markFirstRunComplete("scan_done")
 	}
 
-	// Determine exit code
+	// Determine exit code. Partial results can't vouch for a clean
+	// project, so an incomplete scan exits with at least the warning code.
 	exitCode := determineExitCode(results)
+	if len(incomplete) > 0 && exitCode == 0 {
+		exitCode = 1
+	}
+
+	summary := output.CalculateSummary(results)
+	auditSummary := fmt.Sprintf("%d ok, %d warn, %d fail (exit %d)", summary.OK, summary.Warn, summary.Fail, exitCode)
+	if len(incomplete) > 0 {
+		auditSummary += ", incomplete"
+	}
+	recordAudit(audit.ActionScan, projectDir, cfg.ProjectName, auditSummary)
+	if signKey != nil {
+		recordAudit(audit.ActionSignOff, projectDir, cfg.ProjectName, fmt.Sprintf("signed report with %s", attest.KeyID(signKey.Public().(ed25519.PublicKey))))
+	}
 	if exitCode != 0 {
 		return &ExitError{Code: exitCode}
 	}
@@ -409,7 +542,7 @@ func buildEnabledChecks(cfg *config.PreflightConfig, rootDir string) []checks.Ch
 	// === SEO & Social ===
 	// Auto-enable SEO checks if layout can be detected or explicitly configured
 	seoEnabled := (cfg.Checks.SEOMeta != nil && cfg.Checks.SEOMeta.Enabled) ||
-		canAutoDetectLayout(rootDir, cfg.Stack)
+		detectLayout(rootDir, cfg.Stack) != ""
 	if seoEnabled {
 		enabledChecks = append(enabledChecks, checks.SEOMetadataCheck{})
 		enabledChecks = append(enabledChecks, checks.CanonicalURLCheck{})
@@ -433,6 +566,12 @@ func buildEnabledChecks(cfg *config.PreflightConfig, rootDir string) []checks.Ch
 	if cfg.Checks.EmailAuth != nil && cfg.Checks.EmailAuth.Enabled && cfg.URLs.Production != "" {
 		enabledChecks = append(enabledChecks, checks.EmailAuthCheck{})
 	}
+	if cfg.Checks.IPv6 != nil && cfg.Checks.IPv6.Enabled && cfg.URLs.Production != "" {
+		enabledChecks = append(enabledChecks, checks.IPv6Check{})
+	}
+	if cfg.Checks.MultiRegion != nil && cfg.Checks.MultiRegion.Enabled && cfg.URLs.Production != "" {
+		enabledChecks = append(enabledChecks, checks.MultiRegionCheck{})
+	}
 	if cfg.Checks.Secrets != nil && cfg.Checks.Secrets.Enabled {
 		enabledChecks = append(enabledChecks, checks.SecretScanCheck{})
 	}
@@ -440,7 +579,9 @@ func buildEnabledChecks(cfg *config.PreflightConfig, rootDir string) []checks.Ch
 	// === Environment & Health ===
 	if cfg.Checks.EnvParity != nil && cfg.Checks.EnvParity.Enabled {
 		enabledChecks = append(enabledChecks, checks.EnvParityCheck{})
+		enabledChecks = append(enabledChecks, checks.PlatformEnvCheck{})
 	}
+	enabledChecks = append(enabledChecks, checks.SecretsManagerCheck{})
 	// Health check runs if explicitly enabled OR if any URLs are configured
 	if (cfg.Checks.HealthEndpoint != nil && cfg.Checks.HealthEndpoint.Enabled) ||
 		cfg.URLs.Production != "" || cfg.URLs.Staging != "" {
@@ -463,11 +604,27 @@ func buildEnabledChecks(cfg *config.PreflightConfig, rootDir string) []checks.Ch
 	// === Code Quality & Performance ===
 	enabledChecks = append(enabledChecks, checks.VulnerabilityCheck{})
 	enabledChecks = append(enabledChecks, checks.DebugStatementsCheck{})
+	enabledChecks = append(enabledChecks, checks.DeprecatedServicesCheck{})
+	if cfg.Checks.DeadCode != nil && cfg.Checks.DeadCode.Enabled {
+		enabledChecks = append(enabledChecks, checks.DeadCodeCheck{})
+	}
+	if cfg.Checks.BuildFreshness != nil && cfg.Checks.BuildFreshness.Enabled {
+		enabledChecks = append(enabledChecks, checks.BuildFreshnessCheck{})
+	}
 	enabledChecks = append(enabledChecks, checks.ErrorPagesCheck{})
 	enabledChecks = append(enabledChecks, checks.ImageOptimizationCheck{})
 
 	// === Legal & Compliance ===
 	enabledChecks = append(enabledChecks, checks.LegalPagesCheck{})
+	if cfg.Checks.LegalPlaceholders != nil && cfg.Checks.LegalPlaceholders.Enabled {
+		enabledChecks = append(enabledChecks, checks.LegalPlaceholdersCheck{})
+	}
+	if cfg.Checks.LegalFreshness != nil && cfg.Checks.LegalFreshness.Enabled {
+		enabledChecks = append(enabledChecks, checks.LegalFreshnessCheck{})
+	}
+	// Region-specific legal checks (cookie consent, Impressum, …) are
+	// driven by compliance.regions; see checks.ComplianceChecks.
+	enabledChecks = append(enabledChecks, checks.ComplianceChecks(cfg)...)
 
 	// === Web Standard Files ===
 	enabledChecks = append(enabledChecks, checks.FaviconCheck{})
@@ -484,9 +641,54 @@ func buildEnabledChecks(cfg *config.PreflightConfig, rootDir string) []checks.Ch
 		enabledChecks = append(enabledChecks, checks.LicenseCheck{})
 	}
 
+	// === Polish ===
+	if cfg.Checks.EmailObfuscation != nil && cfg.Checks.EmailObfuscation.Enabled {
+		enabledChecks = append(enabledChecks, checks.EmailObfuscationCheck{})
+	}
+
 	return enabledChecks
 }
 
+// runCheckBefore runs check, giving up when deadline is done. A check that
+// ignores its context (a CPU-bound search, say) is left running in the
+// background; the scan is about to exit anyway.
+func runCheckBefore(deadline context.Context, check checks.Check, ctx checks.Context) (result checks.CheckResult, finished bool, err error) {
+	type outcome struct {
+		result checks.CheckResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		r, err := check.Run(ctx)
+		done <- outcome{r, err}
+	}()
+	select {
+	case o := <-done:
+		return o.result, true, o.err
+	case <-deadline.Done():
+		return checks.CheckResult{}, false, nil
+	}
+}
+
+// scanUserAgent is the User-Agent live checks send: preflight/<version>
+// with a link site owners can follow, or empty (Go's default) when
+// http.identify is false.
+func scanUserAgent(cfg *config.PreflightConfig) string {
+	if !cfg.HTTP.IdentifyEnabled() {
+		return ""
+	}
+	return fmt.Sprintf("preflight/%s; +https://preflight.sh", version)
+}
+
+// checkIDs lists the IDs of cs, for reporting checks a scan didn't run.
+func checkIDs(cs []checks.Check) []string {
+	ids := make([]string, len(cs))
+	for i, c := range cs {
+		ids[i] = c.ID()
+	}
+	return ids
+}
+
 func determineExitCode(results []checks.CheckResult) int {
 	hasError := false
 	hasWarning := false
@@ -511,8 +713,9 @@ func determineExitCode(results []checks.CheckResult) int {
 	return 0
 }
 
-// canAutoDetectLayout checks if a layout file can be auto-detected for SEO checks
-func canAutoDetectLayout(rootDir, stack string) bool {
+// detectLayout returns the layout file the SEO checks can auto-detect, or ""
+// when there is none.
+func detectLayout(rootDir, stack string) string {
 	// Common layout files by stack
 	layoutsByStack := map[string][]string{
 		"next": {
@@ -550,7 +753,7 @@ func canAutoDetectLayout(rootDir, stack string) bool {
 	if layouts, ok := layoutsByStack[stack]; ok {
 		for _, layout := range layouts {
 			if _, err := os.Stat(filepath.Join(rootDir, layout)); err == nil {
-				return true
+				return layout
 			}
 		}
 	}
@@ -563,9 +766,9 @@ func canAutoDetectLayout(rootDir, stack string) bool {
 	}
 	for _, layout := range commonLayouts {
 		if _, err := os.Stat(filepath.Join(rootDir, layout)); err == nil {
-			return true
+			return layout
 		}
 	}
 
-	return false
+	return ""
 }
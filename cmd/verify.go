@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/preflightsh/preflight/internal/attest"
+	"github.com/preflightsh/preflight/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var verifyPubKeyFlag string
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <report.json>",
+	Short: "Verify a signed scan report",
+	Long: `Check that a report written by 'preflight scan --format json --sign' has not
+been edited since it was signed by a trusted key.
+
+The report carries the signer's public key, but anyone who edits a report
+can re-sign it with a key of their own. --pubkey is therefore required: pass
+the expected signer's signing_key.pub. A report with an intact signature from
+any other key fails verification.
+
+Example:
+  preflight scan --format json --sign > report.json
+  preflight verify report.json --pubkey ci-signing_key.pub`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().StringVar(&verifyPubKeyFlag, "pubkey", "", "Public key (PEM) the report must be signed with (required)")
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to read report: %w", err)}
+	}
+
+	// Reject unknown fields: anything added by hand wouldn't be covered
+	// by the signature, so it must not be silently accepted.
+	var report output.JSONOutput
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&report); err != nil {
+		return &ExitError{Code: 1, Err: fmt.Errorf("failed to parse report: %w", err)}
+	}
+	if report.Signature == nil {
+		return &ExitError{Code: 1, Err: fmt.Errorf("report is not signed (scan with --format json --sign)")}
+	}
+
+	payload, err := report.SignedPayload()
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+	signer, err := attest.Verify(*report.Signature, payload)
+	if err != nil {
+		return &ExitError{Code: 1, Err: fmt.Errorf("verification failed: %w", err)}
+	}
+
+	// The embedded key only proves the report wasn't edited after it was
+	// signed by whoever holds that key; only a pinned key says who that is.
+	if verifyPubKeyFlag == "" {
+		return &ExitError{Code: 1, Err: fmt.Errorf("signature is intact but signer %s is not trusted: pass --pubkey with the expected signer's public key", attest.KeyID(signer))}
+	}
+	trusted, err := attest.LoadPublicKey(verifyPubKeyFlag)
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to load --pubkey: %w", err)}
+	}
+	if !trusted.Equal(signer) {
+		return &ExitError{Code: 1, Err: fmt.Errorf("verification failed: signed by %s, not by %s (%s)", attest.KeyID(signer), attest.KeyID(trusted), verifyPubKeyFlag)}
+	}
+
+	fmt.Printf("✓ Signature valid for %q (%d ok, %d warn, %d fail)\n", report.Project, report.Summary.OK, report.Summary.Warn, report.Summary.Fail)
+	fmt.Printf("  Signed by %s (%s)\n", attest.KeyID(signer), verifyPubKeyFlag)
+	return nil
+}
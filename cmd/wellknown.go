@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/wellknown"
+)
+
+// wellKnownFile is a site file init can generate: where it's written
+// (relative to the web root) and where an existing copy may already be.
+type wellKnownFile struct {
+	name     string
+	path     string
+	existing []string
+}
+
+var wellKnownFiles = []wellKnownFile{
+	{name: "security.txt", path: ".well-known/security.txt", existing: []string{".well-known/security.txt", "security.txt"}},
+	{name: "humans.txt", path: "humans.txt", existing: []string{"humans.txt"}},
+	{name: "llms.txt", path: "llms.txt", existing: []string{"llms.txt", ".well-known/llms.txt"}},
+}
+
+// generateWellKnownFiles offers to write security.txt, humans.txt and
+// llms.txt into wellKnownRoot when the project doesn't have them,
+// prompting for the security contact, team credits and site summary. A
+// blank answer skips that file. It returns whether humans.txt was written,
+// so init can turn on the humansTxt check.
+func generateWellKnownFiles(reader *bufio.Reader, cwd, projectName, stack, productionURL string) (wroteHumans bool) {
+	webRoot, hint := wellKnownRoot(cwd, stack)
+	var missing []wellKnownFile
+	for _, f := range wellKnownFiles {
+		if !wellKnownExists(cwd, webRoot, f.existing) {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) == 0 {
+		return false
+	}
+
+	names := make([]string, len(missing))
+	for i, f := range missing {
+		names[i] = f.name
+	}
+	where := webRoot + "/"
+	if webRoot == "." {
+		where = "the project root"
+	}
+	fmt.Println()
+	if !promptYesNo(reader, fmt.Sprintf("Generate %s in %s?", strings.Join(names, ", "), where), true) {
+		return false
+	}
+
+	now := time.Now()
+	wrote := false
+	for _, f := range missing {
+		var content string
+		switch f.name {
+		case "security.txt":
+			contact := securityContact(promptOptional(reader, "  Security contact (email, https:// URL or tel:; blank to skip)"))
+			if contact == "" {
+				continue
+			}
+			content = wellknown.SecurityTxt(contact, now.AddDate(1, 0, 0), productionURL)
+		case "humans.txt":
+			team := wellknown.ParseTeamCredits(promptOptional(reader, "  Team credits (e.g. \"Jane Doe - Developer, Sam Lee - Design\"; blank to skip)"))
+			if len(team) == 0 {
+				continue
+			}
+			content = wellknown.HumansTxt(team, formatStackName(stack), now)
+		case "llms.txt":
+			summary := promptOptional(reader, "  One-sentence site summary for llms.txt (blank to skip)")
+			if summary == "" {
+				continue
+			}
+			content = wellknown.LLMsTxt(projectName, summary, productionURL)
+		}
+
+		rel := filepath.Join(webRoot, f.path)
+		full := filepath.Join(cwd, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			fmt.Printf("  ⚠️  Could not create directory: %v\n", err)
+			continue
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			fmt.Printf("  ⚠️  Could not create %s: %v\n", rel, err)
+			continue
+		}
+		fmt.Printf("  ✅ Created %s\n", filepath.ToSlash(rel))
+		wrote = true
+		if f.name == "humans.txt" {
+			wroteHumans = true
+		}
+	}
+	if wrote && hint != "" {
+		fmt.Printf("     %s\n", hint)
+	}
+	return wroteHumans
+}
+
+// wellKnownExists reports whether any of paths exists in the web root or
+// the project root.
+func wellKnownExists(cwd, webRoot string, paths []string) bool {
+	for _, root := range []string{webRoot, ""} {
+		for _, p := range paths {
+			if _, err := os.Stat(filepath.Join(cwd, root, p)); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// securityContact validates the answer as a security.txt Contact value,
+// warning when it isn't an email address, https:// URL or tel: URI. It
+// returns "" for a blank or invalid answer.
+func securityContact(answer string) string {
+	if answer == "" {
+		return ""
+	}
+	contact, ok := wellknown.SecurityContact(answer)
+	if !ok {
+		fmt.Printf("  ⚠️  %q isn't an email address, https:// URL or tel: URI, skipping security.txt\n", answer)
+	}
+	return contact
+}
+
+// wellKnownRoot returns the directory init writes the generated files to.
+// That's the web root, except where the web root is build output (Jekyll
+// and Eleventy's _site, dist, build, out), which the next build wipes;
+// those stacks get the files in their source tree instead, along with a
+// hint for getting the generator to publish them.
+func wellKnownRoot(cwd, stack string) (root, hint string) {
+	webRoot := detectWebRoot(cwd, stack)
+	switch webRoot {
+	case "_site", "dist", "build", "out":
+	default:
+		return webRoot, ""
+	}
+	switch stack {
+	case "jekyll":
+		return ".", `Jekyll skips dot-directories: add include: [".well-known"] to _config.yml`
+	case "eleventy":
+		return ".", `Eleventy doesn't copy .txt files: add addPassthroughCopy for .well-known, humans.txt and llms.txt`
+	}
+	return "public", fmt.Sprintf("Make sure your build copies public/ into %s/", webRoot)
+}
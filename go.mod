@@ -7,11 +7,11 @@ require (
 	github.com/spf13/cobra v1.10.2
 	golang.org/x/image v0.38.0
 	golang.org/x/mod v0.35.0
+	golang.org/x/net v0.55.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
-	golang.org/x/net v0.55.0 // indirect
 )
@@ -0,0 +1,261 @@
+// Package alert turns repeated scans into change notifications. Scheduled
+// runs (cron, CI schedules) compare each check with its state from the
+// previous run and call a webhook only when a check changes state or keeps
+// flipping, instead of reporting the full summary every time.
+//
+// State lives at ~/.preflight/alerts.json, keyed by project.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/checks"
+)
+
+// Check states, from best to worst.
+const (
+	StatusOK    = "ok"
+	StatusWarn  = "warn"
+	StatusError = "error"
+)
+
+// Policy controls when a state change is worth an alert.
+type Policy struct {
+	// Cooldown is the minimum time between two alerts for the same check.
+	Cooldown time.Duration
+	// FlapThreshold state changes within FlapWindow mark a check as
+	// flapping. Zero disables flap detection.
+	FlapThreshold int
+	FlapWindow    time.Duration
+}
+
+// CheckState is what's remembered about one check between runs.
+type CheckState struct {
+	Status string    `json:"status"`
+	Since  time.Time `json:"since"`
+	// Reported is the status the last alert announced (or the baseline).
+	// A change that lands inside the cooldown is reported once it ends.
+	Reported  string    `json:"reported"`
+	LastAlert time.Time `json:"last_alert,omitzero"`
+	// Changes are the times of recent state changes, oldest first, pruned
+	// to the flap window.
+	Changes []time.Time `json:"changes,omitempty"`
+}
+
+// State is one project's check states, keyed by check ID.
+type State map[string]CheckState
+
+// Alert is one check worth notifying about.
+type Alert struct {
+	Check    string `json:"check"`
+	Title    string `json:"title"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Message  string `json:"message,omitempty"`
+	Flapping bool   `json:"flapping,omitempty"`
+}
+
+// Status maps a check result to ok, warn or error.
+func Status(r checks.CheckResult) string {
+	switch {
+	case r.Passed:
+		return StatusOK
+	case r.Severity == checks.SeverityError:
+		return StatusError
+	}
+	return StatusWarn
+}
+
+// Evaluate compares results with the previous state and returns the alerts
+// to send and the state to save. A check seen for the first time sets a
+// baseline without alerting. A check alerts when its status differs from
+// the last one reported, or when it is flapping and has changed since its
+// last alert, at most once per cooldown. Checks that didn't run this time
+// keep their previous state, so --only runs don't reset them.
+func Evaluate(prev State, results []checks.CheckResult, now time.Time, p Policy) ([]Alert, State) {
+	next := make(State, len(prev)+len(results))
+	for id, s := range prev {
+		next[id] = s
+	}
+
+	var alerts []Alert
+	for _, r := range results {
+		status := Status(r)
+		s, seen := prev[r.ID]
+		if !seen {
+			next[r.ID] = CheckState{Status: status, Since: now, Reported: status}
+			continue
+		}
+
+		last := s.Status
+		s.Changes = recentChanges(s.Changes, now, p.FlapWindow)
+		if status != s.Status {
+			s.Status = status
+			s.Since = now
+			s.Changes = append(s.Changes, now)
+		}
+		flapping := p.FlapThreshold > 0 && len(s.Changes) >= p.FlapThreshold
+		changedSinceAlert := len(s.Changes) > 0 && s.Changes[len(s.Changes)-1].After(s.LastAlert)
+
+		due := status != s.Reported || (flapping && changedSinceAlert)
+		if due && now.Sub(s.LastAlert) >= p.Cooldown {
+			// A flapping check can be back where it was last reported;
+			// show the flip it just made instead.
+			from := s.Reported
+			if from == status {
+				from = last
+			}
+			alerts = append(alerts, Alert{
+				Check:    r.ID,
+				Title:    r.Title,
+				From:     from,
+				To:       status,
+				Message:  r.Message,
+				Flapping: flapping,
+			})
+			s.Reported = status
+			s.LastAlert = now
+		}
+		next[r.ID] = s
+	}
+	return alerts, next
+}
+
+// recentChanges drops change times older than window. A zero window keeps
+// none, since there is no flap detection to feed.
+func recentChanges(changes []time.Time, now time.Time, window time.Duration) []time.Time {
+	var kept []time.Time
+	for _, t := range changes {
+		if window > 0 && now.Sub(t) < window {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// DefaultPath returns ~/.preflight/alerts.json.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".preflight", "alerts.json"), nil
+}
+
+// Load reads project's state from the file at path. A missing file or
+// project yields an empty state.
+func Load(path, project string) (State, error) {
+	all, err := loadAll(path)
+	if err != nil {
+		return nil, err
+	}
+	if s := all[project]; s != nil {
+		return s, nil
+	}
+	return State{}, nil
+}
+
+// Save replaces project's state in the file at path, keeping every other
+// project's, and writes it with 0600 perms.
+func Save(path, project string, s State) error {
+	all, err := loadAll(path)
+	if err != nil {
+		return err
+	}
+	all[project] = s
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func loadAll(path string) (map[string]State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]State{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return all, nil
+}
+
+// Payload is the JSON body POSTed to the webhook. Text is a ready-made
+// summary, so Slack and other incoming webhooks that read "text" work
+// without a template.
+type Payload struct {
+	Project string  `json:"project"`
+	URL     string  `json:"url,omitempty"`
+	Text    string  `json:"text"`
+	Alerts  []Alert `json:"alerts"`
+}
+
+// NewPayload builds the webhook body for alerts, worst first.
+func NewPayload(project, url string, alerts []Alert) Payload {
+	sorted := append([]Alert(nil), alerts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i].To) > rank(sorted[j].To)
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Preflight: %d check(s) changed state for %s", len(sorted), project)
+	for _, a := range sorted {
+		fmt.Fprintf(&b, "\n• %s: %s → %s", a.Title, a.From, a.To)
+		if a.Flapping {
+			b.WriteString(" (flapping)")
+		}
+		if a.To != StatusOK && a.Message != "" {
+			fmt.Fprintf(&b, ": %s", a.Message)
+		}
+	}
+	return Payload{Project: project, URL: url, Text: b.String(), Alerts: sorted}
+}
+
+func rank(status string) int {
+	switch status {
+	case StatusError:
+		return 2
+	case StatusWarn:
+		return 1
+	}
+	return 0
+}
+
+// Send POSTs p as JSON to webhook.
+func Send(ctx context.Context, client *http.Client, webhook string, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/checks"
+)
+
+func result(id string, passed bool, sev checks.Severity) checks.CheckResult {
+	return checks.CheckResult{ID: id, Title: id, Passed: passed, Severity: sev, Message: id + " message"}
+}
+
+var (
+	ok      = func(id string) checks.CheckResult { return result(id, true, checks.SeverityInfo) }
+	warn    = func(id string) checks.CheckResult { return result(id, false, checks.SeverityWarn) }
+	failing = func(id string) checks.CheckResult { return result(id, false, checks.SeverityError) }
+)
+
+func TestEvaluateTransitions(t *testing.T) {
+	p := Policy{Cooldown: time.Hour, FlapThreshold: 3, FlapWindow: 24 * time.Hour}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	alerts, state := Evaluate(State{}, []checks.CheckResult{ok("ssl"), warn("seo")}, t0, p)
+	if len(alerts) != 0 {
+		t.Fatalf("first run alerted: %+v", alerts)
+	}
+
+	// Unchanged: nothing to say.
+	alerts, state = Evaluate(state, []checks.CheckResult{ok("ssl"), warn("seo")}, t0.Add(2*time.Hour), p)
+	if len(alerts) != 0 {
+		t.Fatalf("unchanged run alerted: %+v", alerts)
+	}
+
+	// pass→fail and warn→error both alert.
+	alerts, state = Evaluate(state, []checks.CheckResult{failing("ssl"), failing("seo")}, t0.Add(4*time.Hour), p)
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2: %+v", len(alerts), alerts)
+	}
+	if a := alerts[0]; a.Check != "ssl" || a.From != StatusOK || a.To != StatusError || a.Flapping {
+		t.Errorf("ssl alert = %+v", a)
+	}
+	if a := alerts[1]; a.Check != "seo" || a.From != StatusWarn || a.To != StatusError {
+		t.Errorf("seo alert = %+v", a)
+	}
+
+	// A check missing from this run (e.g. --only) keeps its state.
+	_, state = Evaluate(state, []checks.CheckResult{failing("seo")}, t0.Add(5*time.Hour), p)
+	if state["ssl"].Status != StatusError {
+		t.Errorf("ssl state = %+v, want kept", state["ssl"])
+	}
+}
+
+func TestEvaluateCooldown(t *testing.T) {
+	p := Policy{Cooldown: time.Hour}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, state := Evaluate(State{}, []checks.CheckResult{ok("ssl")}, t0, p)
+	alerts, state := Evaluate(state, []checks.CheckResult{failing("ssl")}, t0.Add(time.Minute), p)
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(alerts))
+	}
+
+	// Recovery inside the cooldown is held back...
+	alerts, state = Evaluate(state, []checks.CheckResult{ok("ssl")}, t0.Add(10*time.Minute), p)
+	if len(alerts) != 0 {
+		t.Fatalf("alert inside cooldown: %+v", alerts)
+	}
+	// ...and reported on the first run after it.
+	alerts, _ = Evaluate(state, []checks.CheckResult{ok("ssl")}, t0.Add(2*time.Hour), p)
+	if len(alerts) != 1 || alerts[0].From != StatusError || alerts[0].To != StatusOK {
+		t.Fatalf("alerts after cooldown = %+v, want error → ok", alerts)
+	}
+}
+
+func TestEvaluateFlapping(t *testing.T) {
+	p := Policy{Cooldown: time.Hour, FlapThreshold: 3, FlapWindow: 24 * time.Hour}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, state := Evaluate(State{}, []checks.CheckResult{ok("health")}, t0, p)
+	var alerts []Alert
+	for i, r := range []checks.CheckResult{failing("health"), ok("health"), failing("health"), ok("health")} {
+		var got []Alert
+		got, state = Evaluate(state, []checks.CheckResult{r}, t0.Add(time.Duration(i+1)*2*time.Hour), p)
+		alerts = append(alerts, got...)
+	}
+	if len(alerts) != 4 {
+		t.Fatalf("got %d alerts, want 4: %+v", len(alerts), alerts)
+	}
+	if alerts[1].Flapping || !alerts[2].Flapping || !alerts[3].Flapping {
+		t.Errorf("flapping = %v %v %v %v, want false false true true", alerts[0].Flapping, alerts[1].Flapping, alerts[2].Flapping, alerts[3].Flapping)
+	}
+
+	// Changes outside the window no longer count.
+	alerts, _ = Evaluate(state, []checks.CheckResult{failing("health")}, t0.Add(72*time.Hour), p)
+	if len(alerts) != 1 || alerts[0].Flapping {
+		t.Errorf("alert after window = %+v, want one, not flapping", alerts)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+
+	s, err := Load(path, "git:abc")
+	if err != nil || len(s) != 0 {
+		t.Fatalf("Load(missing) = %v, %v", s, err)
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := Save(path, "git:abc", State{"ssl": {Status: StatusOK, Since: now, Reported: StatusOK}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(path, "name:other", State{"seo": {Status: StatusWarn}}); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Load(path, "git:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s["ssl"]; got.Status != StatusOK || !got.Since.Equal(now) {
+		t.Errorf("loaded %+v", got)
+	}
+	if s, _ := Load(path, "name:other"); s["seo"].Status != StatusWarn {
+		t.Errorf("other project's state lost: %+v", s)
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	p := NewPayload("site", "https://example.com", []Alert{
+		{Check: "seo", Title: "SEO", From: StatusOK, To: StatusWarn, Message: "missing title"},
+		{Check: "ssl", Title: "SSL", From: StatusOK, To: StatusError, Message: "expired", Flapping: true},
+	})
+	if err := Send(t.Context(), srv.Client(), srv.URL, p); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Alerts) != 2 || got.Alerts[0].Check != "ssl" {
+		t.Errorf("alerts = %+v, want worst first", got.Alerts)
+	}
+	if !strings.Contains(got.Text, "SSL: ok → error (flapping): expired") {
+		t.Errorf("text = %q", got.Text)
+	}
+}
@@ -0,0 +1,159 @@
+// Package attest signs and verifies scan reports so a JSON report attached
+// to a release ticket can be shown not to have been edited after the scan.
+//
+// Reports are signed with a local Ed25519 key kept in ~/.preflight
+// (created on first use). The signature travels inside the report along
+// with the public key; `preflight verify --pubkey` pins the expected key.
+package attest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Algorithm is the only signature scheme currently produced.
+const Algorithm = "ed25519"
+
+// ErrBadSignature is returned by Verify when the report doesn't match its
+// signature.
+var ErrBadSignature = errors.New("signature does not match report contents")
+
+// Signature is embedded in a signed JSON report.
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId"`
+	PublicKey string `json:"publicKey"`
+	Value     string `json:"value"`
+}
+
+// DefaultKeyPath returns ~/.preflight/signing_key.
+func DefaultKeyPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".preflight", "signing_key"), nil
+}
+
+// LoadOrCreateKey reads the PEM-encoded private key at path, generating a
+// new one (and writing its public half to path+".pub") when none exists.
+func LoadOrCreateKey(path string) (ed25519.PrivateKey, bool, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read signing key %s: %w", path, err)
+		}
+		return key, false, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, false, err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, false, err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(path+".pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o644); err != nil {
+		return nil, false, err
+	}
+	return priv, true, nil
+}
+
+// LoadPublicKey reads a PEM-encoded public key, as written next to the
+// signing key by LoadOrCreateKey.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%s is not a PEM public key", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 key", path)
+	}
+	return pub, nil
+}
+
+func parsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("not a PEM private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 key")
+	}
+	return priv, nil
+}
+
+// KeyID is a short, stable fingerprint of a public key for display.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return Algorithm + ":" + hex.EncodeToString(sum[:8])
+}
+
+// Sign signs payload with key.
+func Sign(key ed25519.PrivateKey, payload []byte) Signature {
+	pub := key.Public().(ed25519.PublicKey)
+	return Signature{
+		Algorithm: Algorithm,
+		KeyID:     KeyID(pub),
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+	}
+}
+
+// Verify checks sig against payload using the public key embedded in sig,
+// returning that key so the caller can decide whether it's trusted.
+func Verify(sig Signature, payload []byte) (ed25519.PublicKey, error) {
+	if sig.Algorithm != Algorithm {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", sig.Algorithm)
+	}
+	pub, err := base64.StdEncoding.DecodeString(sig.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, errors.New("malformed public key in signature")
+	}
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return nil, errors.New("malformed signature value")
+	}
+	if !ed25519.Verify(pub, payload, value) {
+		return nil, ErrBadSignature
+	}
+	return ed25519.PublicKey(pub), nil
+}
@@ -0,0 +1,36 @@
+package attest
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signing_key")
+	key, created, err := LoadOrCreateKey(path)
+	if err != nil || !created {
+		t.Fatalf("LoadOrCreateKey = %v, created %v", err, created)
+	}
+	again, created, err := LoadOrCreateKey(path)
+	if err != nil || created || !again.Equal(key) {
+		t.Fatalf("reloading key: err %v, created %v, same %v", err, created, again.Equal(key))
+	}
+
+	payload := []byte(`{"project":"x","summary":{"ok":1,"warn":0,"fail":0}}`)
+	sig := Sign(key, payload)
+
+	pub, err := Verify(sig, payload)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	filePub, err := LoadPublicKey(path + ".pub")
+	if err != nil || !filePub.Equal(pub) {
+		t.Fatalf("LoadPublicKey = %v, matches %v", err, filePub.Equal(pub))
+	}
+
+	tampered := []byte(`{"project":"x","summary":{"ok":2,"warn":0,"fail":0}}`)
+	if _, err := Verify(sig, tampered); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Verify(tampered) = %v, want ErrBadSignature", err)
+	}
+}
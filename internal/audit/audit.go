@@ -0,0 +1,210 @@
+// Package audit keeps an append-only local log of launch-gate decisions
+// (scans, inits, ignores, sign-offs) so regulated teams can show who
+// changed what and when.
+//
+// The log lives at ~/.preflight/audit.log, one JSON entry per line. Each
+// entry records the SHA-256 of the line before it, so editing or deleting an
+// entry breaks the chain at the entry after it and `preflight log` reports
+// it. Nothing comes after the newest entries, so cutting entries off the end
+// of the log can't be detected from the log alone.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Actions recorded in the log.
+const (
+	ActionScan     = "scan"
+	ActionInit     = "init"
+	ActionIgnore   = "ignore"
+	ActionUnignore = "unignore"
+	ActionSignOff  = "sign-off"
+)
+
+// Entry is one line of the audit log.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	User    string    `json:"user,omitempty"`
+	Email   string    `json:"email,omitempty"`
+	Project string    `json:"project,omitempty"`
+	Dir     string    `json:"dir"`
+	Summary string    `json:"summary"`
+	// Prev is the hex SHA-256 of the previous line, or empty for the
+	// first entry.
+	Prev string `json:"prev,omitempty"`
+}
+
+// DefaultPath returns ~/.preflight/audit.log.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".preflight", "audit.log"), nil
+}
+
+// NewEntry fills in the time, the absolute project directory and the git
+// user (user.name/user.email as git resolves them for dir).
+func NewEntry(action, dir, project, summary string) Entry {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return Entry{
+		Time:    time.Now().UTC().Truncate(time.Second),
+		Action:  action,
+		User:    gitConfig(dir, "user.name"),
+		Email:   gitConfig(dir, "user.email"),
+		Project: project,
+		Dir:     dir,
+		Summary: summary,
+	}
+}
+
+func gitConfig(dir, key string) string {
+	out, err := exec.Command("git", "-C", dir, "config", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Lock file timings: how long Append waits for another preflight process,
+// and the age after which a lock is assumed left behind by a crash.
+const (
+	lockTimeout = 5 * time.Second
+	staleLock   = 30 * time.Second
+)
+
+// Append chains e to the last entry in the log at path and appends it,
+// creating the log (0600) if needed. Appends are serialized across
+// processes with a lock file next to the log, so concurrent scans can't
+// chain two entries to the same predecessor.
+func Append(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	unlock, err := lock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	last, err := lastLine(f)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if last != nil {
+		e.Prev = hashLine(last)
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// lock creates lockPath exclusively, waiting up to lockTimeout for another
+// holder to remove it, and returns the function that releases it.
+func lock(lockPath string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("audit log is locked by another preflight process (remove %s if none is running)", lockPath)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// lastLine returns the final non-empty line of f, or nil if f is empty.
+func lastLine(f *os.File) ([]byte, error) {
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, err
+	}
+	var last []byte
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		if line := bytes.TrimSpace(sc.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	return last, sc.Err()
+}
+
+func hashLine(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
+
+// Read returns every entry in the log at path, oldest first, and the
+// 1-based line numbers where the hash chain is broken (an entry whose
+// Prev doesn't match the line before it). A missing log yields no
+// entries and no error.
+func Read(path string) ([]Entry, []int, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	var broken []int
+	prev := ""
+	n := 0
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		n++
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			broken = append(broken, n)
+			prev = hashLine(line)
+			continue
+		}
+		if e.Prev != prev {
+			broken = append(broken, n)
+		}
+		entries = append(entries, e)
+		prev = hashLine(line)
+	}
+	return entries, broken, sc.Err()
+}
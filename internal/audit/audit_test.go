@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAppendReadChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	entries, broken, err := Read(path)
+	if err != nil || len(entries) != 0 || len(broken) != 0 {
+		t.Fatalf("Read(missing) = %v, %v, %v", entries, broken, err)
+	}
+
+	dir := t.TempDir()
+	for _, e := range []Entry{
+		NewEntry(ActionInit, dir, "site", "created preflight.yml"),
+		NewEntry(ActionIgnore, dir, "site", "ignored sitemap"),
+		NewEntry(ActionScan, dir, "site", "12 ok, 1 warn, 0 fail"),
+	} {
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	entries, broken, err = Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 3 || len(broken) != 0 {
+		t.Fatalf("got %d entries, broken %v; want 3, none", len(entries), broken)
+	}
+	if entries[0].Prev != "" || entries[1].Prev == "" {
+		t.Errorf("Prev = %q, %q; want empty then a hash", entries[0].Prev, entries[1].Prev)
+	}
+	if entries[2].Action != ActionScan || entries[2].Dir != dir {
+		t.Errorf("last entry = %+v", entries[2])
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0600 {
+		t.Errorf("audit log mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestReadDetectsEditedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	dir := t.TempDir()
+	for _, summary := range []string{"ignored sitemap", "3 ok, 0 warn, 1 fail", "3 ok, 0 warn, 0 fail"} {
+		if err := Append(path, NewEntry(ActionScan, dir, "site", summary)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), "1 fail", "0 fail", 1)
+	if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, broken, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(broken) != 1 || broken[0] != 3 {
+		t.Errorf("broken = %v, want [3] (the entry after the edited one)", broken)
+	}
+}
+
+func TestAppendConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := Append(path, NewEntry(ActionScan, dir, "site", "scan")); err != nil {
+				t.Errorf("Append: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, broken, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(entries) != 20 || len(broken) != 0 {
+		t.Errorf("got %d entries with breaks at %v, want 20 and an unbroken chain", len(entries), broken)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package checks
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+// ageGatePatterns fingerprint an age-verification interstitial: the usual
+// "are you 21?" prompt, a date-of-birth form, or a known age-gate plugin.
+var ageGatePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)are\s+you\s+((over|at\s+least)\s+(the\s+age\s+of\s+)?(1[89]|2[01])|(1[89]|2[01])(\s+or\s+older|\+)|of\s+(legal\s+)?(drinking\s+|gambling\s+)?age)`),
+	regexp.MustCompile(`(?i)(i\s+am|i'm)\s+(over|at\s+least)\s+(1[89]|2[01])`),
+	regexp.MustCompile(`(?i)(legal\s+drinking|legal\s+gambling|minimum)\s+age`),
+	regexp.MustCompile(`(?i)\bage[-_ ]?(gate|verification|verify|check)`),
+	regexp.MustCompile(`(?i)(date\s+of\s+birth|enter\s+your\s+(birth\s*date|age))`),
+}
+
+// responsibleUsePatterns are the footer notices each regulated vertical is
+// expected to carry.
+var responsibleUsePatterns = map[string][]*regexp.Regexp{
+	config.VerticalAlcohol: {
+		regexp.MustCompile(`(?i)(drink|enjoy)\s+responsibly`),
+		regexp.MustCompile(`(?i)drinkaware|responsibility\.org`),
+	},
+	config.VerticalGambling: {
+		regexp.MustCompile(`(?i)(gamble|play)\s+responsibly`),
+		regexp.MustCompile(`(?i)begambleaware|gamstop|1-800-gambler|gamblingtherapy|problem\s+gambling`),
+	},
+	config.VerticalVaping: {
+		regexp.MustCompile(`(?i)contains\s+nicotine|nicotine\s+is\s+an\s+addictive`),
+		regexp.MustCompile(`(?i)not\s+for\s+sale\s+to\s+minors`),
+	},
+}
+
+// responsibleUseExamples is the suggested wording per vertical.
+var responsibleUseExamples = map[string]string{
+	config.VerticalAlcohol:  "Add a \"Please drink responsibly\" notice (plus a link to Drinkaware or responsibility.org) to the footer",
+	config.VerticalGambling: "Add a \"Gamble responsibly\" notice with a helpline link (BeGambleAware, GamStop, 1-800-GAMBLER) to the footer",
+	config.VerticalVaping:   "Add the nicotine warning (\"This product contains nicotine. Nicotine is an addictive chemical.\") to the footer",
+}
+
+// AgeGateCheck verifies sites in a regulated vertical (compliance.vertical)
+// show an age-verification gate on the live homepage and carry a
+// responsible-use notice.
+type AgeGateCheck struct{}
+
+func (c AgeGateCheck) ID() string {
+	return "age_gate"
+}
+
+func (c AgeGateCheck) Title() string {
+	return "Age gate & responsible-use notice"
+}
+
+func (c AgeGateCheck) Run(ctx Context) (CheckResult, error) {
+	vertical := ctx.Config.Compliance.Vertical
+	if vertical == "" {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No regulated vertical configured, skipping",
+		}, nil
+	}
+
+	// The gate has to be live to matter, so prefer production's rendered
+	// homepage. Without one, fall back to the codebase and say so.
+	page := ctx.PageHTMLProduction
+	if page == "" {
+		page = ctx.PageHTML
+	}
+	live := page != ""
+
+	found := func(patterns []*regexp.Regexp) bool {
+		if live {
+			for _, p := range patterns {
+				if p.MatchString(page) {
+					return true
+				}
+			}
+			return false
+		}
+		return searchForPatterns(ctx.RootDir, ctx.Config.Stack, patterns)
+	}
+
+	var missing []string
+	var suggestions []string
+	if !found(ageGatePatterns) {
+		missing = append(missing, "age verification gate")
+		suggestions = append(suggestions, "Show an age gate (\"Are you 21 or older?\" or a date-of-birth prompt) before any product content")
+	}
+	if !found(responsibleUsePatterns[vertical]) {
+		missing = append(missing, "responsible-use notice")
+		suggestions = append(suggestions, responsibleUseExamples[vertical])
+	}
+
+	where := "live homepage"
+	if !live {
+		where = "codebase (no live homepage to check)"
+	}
+
+	if len(missing) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Age gate and " + vertical + " responsible-use notice found in " + where,
+		}, nil
+	}
+
+	return CheckResult{
+		ID:          c.ID(),
+		Title:       c.Title(),
+		Severity:    SeverityWarn,
+		Passed:      false,
+		Message:     "Regulated vertical (" + vertical + ") missing " + strings.Join(missing, " and ") + " in " + where,
+		Suggestions: suggestions,
+	}, nil
+}
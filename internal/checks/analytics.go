@@ -61,6 +61,13 @@ func (c FathomCheck) Run(ctx Context) (CheckResult, error) {
 	}, nil
 }
 
+// GA4 measurement IDs and Google Tag Manager container IDs, used to tell a
+// migrated setup apart from a Universal Analytics-only one.
+var (
+	reGA4MeasurementID = regexp.MustCompile(`\bG-[A-Z0-9]{6,12}\b`)
+	reGTMContainerID   = regexp.MustCompile(`\bGTM-[A-Z0-9]{4,10}\b`)
+)
+
 // GoogleAnalyticsCheck verifies Google Analytics is properly set up
 type GoogleAnalyticsCheck struct{}
 
@@ -97,6 +104,34 @@ func (c GoogleAnalyticsCheck) Run(ctx Context) (CheckResult, error) {
 	found := searchForPatterns(ctx.RootDir, ctx.Config.Stack, patterns)
 
 	if found {
+		// Universal Analytics stopped processing hits in July 2024, so a
+		// UA-only setup is configured but collects nothing. A GTM container
+		// may carry the GA4 tag itself, so it counts as migrated.
+		if uaFile, ok := findPatternLocation(ctx.RootDir, ctx.Config.Stack, []*regexp.Regexp{reUniversalAnalyticsID}); ok {
+			ga4Patterns := []*regexp.Regexp{reGA4MeasurementID, reGTMContainerID}
+			hasGA4 := searchForPatterns(ctx.RootDir, ctx.Config.Stack, ga4Patterns)
+			page := parseRenderedHTML(ctx.PageHTML)
+			for _, p := range ga4Patterns {
+				if !hasGA4 && page.loadsScript(p) {
+					hasGA4 = true
+				}
+			}
+			if !hasGA4 {
+				return CheckResult{
+					ID:       c.ID(),
+					Title:    c.Title(),
+					Severity: SeverityWarn,
+					Passed:   false,
+					Message:  "Only a Universal Analytics (UA-) ID found in " + uaFile + "; UA no longer collects data",
+					Suggestions: []string{
+						"Create a GA4 property in Google Analytics and copy its G-XXXXXXX measurement ID",
+						"Replace analytics.js/ga('create', 'UA-…') with the gtag.js snippet for the G- ID",
+						"Remove the UA- ID once GA4 is live; historical UA data is no longer accessible",
+					},
+				}, nil
+			}
+		}
+
 		return CheckResult{
 			ID:       c.ID(),
 			Title:    c.Title(),
@@ -366,7 +401,7 @@ func searchForPatterns(rootDir, stack string, patterns []*regexp.Regexp) bool {
 		}
 
 		found := false
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || found {
 				return nil
 			}
@@ -513,7 +548,7 @@ func searchForPatternsWithDetails(rootDir, stack string, patterns []*regexp.Rege
 			continue
 		}
 
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || result != nil {
 				return nil
 			}
@@ -574,6 +609,15 @@ func searchForPatternsWithDetails(rootDir, stack string, patterns []*regexp.Rege
 	return nil
 }
 
+// findPatternLocation reports the file where any pattern matches outside a
+// comment.
+func findPatternLocation(rootDir, stack string, patterns []*regexp.Regexp) (string, bool) {
+	if match := searchForPatternsWithDetails(rootDir, stack, patterns); match != nil {
+		return match.FilePath, true
+	}
+	return "", false
+}
+
 func getLayoutFilesForStack(stack string) []string {
 	layouts := map[string][]string{
 		// Backend Frameworks
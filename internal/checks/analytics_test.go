@@ -0,0 +1,40 @@
+package checks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestGoogleAnalyticsUniversalOnly(t *testing.T) {
+	run := func(t *testing.T, layout string) checks.CheckResult {
+		p := checktest.NewProject(t, map[string]string{"index.html": layout})
+		p.Config.Stack = "static"
+		p.Config.Services = map[string]config.ServiceConfig{"google_analytics": {Declared: true}}
+		return p.Run(checks.GoogleAnalyticsCheck{})
+	}
+
+	t.Run("warns when only a UA- ID is present", func(t *testing.T) {
+		res := run(t, `<script>ga('create', 'UA-1234567-1', 'auto');</script>`)
+		if res.Passed || !strings.Contains(res.Message, "Universal Analytics") {
+			t.Fatalf("expected UA-only warning, got passed=%v %q", res.Passed, res.Message)
+		}
+	})
+
+	t.Run("warns when the UA- ID is only in the gtag.js URL", func(t *testing.T) {
+		res := run(t, `<script async src="https://www.googletagmanager.com/gtag/js?id=UA-1234567-1"></script>`)
+		if res.Passed || !strings.Contains(res.Message, "index.html") {
+			t.Fatalf("expected UA-only warning for index.html, got passed=%v %q", res.Passed, res.Message)
+		}
+	})
+
+	t.Run("passes when GA4 sits alongside UA", func(t *testing.T) {
+		res := run(t, `<script>gtag('config', 'UA-1234567-1'); gtag('config', 'G-ABC123XYZ');</script>`)
+		if !res.Passed {
+			t.Fatalf("expected pass, got %q", res.Message)
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// buildTarget pairs a build output directory with the source directories
+// it's generated from. An empty sources list means "the whole project".
+type buildTarget struct {
+	output  string
+	sources []string
+}
+
+// stackBuildTargets lists each static/bundled stack's output directory.
+// Stacks not listed fall back to the generic dist/ and build/ outputs.
+var stackBuildTargets = map[string][]buildTarget{
+	"hugo":     {{"public", []string{"content", "layouts", "static", "assets", "themes", "data", "i18n"}}},
+	"jekyll":   {{"_site", nil}},
+	"eleventy": {{"_site", nil}},
+	"gatsby":   {{"public", []string{"src", "static", "content"}}},
+	"astro":    {{"dist", []string{"src", "public"}}},
+	"next":     {{"out", []string{"app", "pages", "src", "components", "public"}}},
+}
+
+var genericBuildTargets = []buildTarget{
+	{"dist", []string{"src"}},
+	{"build", []string{"src"}},
+}
+
+// buildMetaFiles are top-level files that never feed a build: the repo's
+// own docs, licenses and tool config. They're left out when a target's
+// sources are the whole project, so committing a README or preflight.yml
+// doesn't mark the build stale.
+var buildMetaFiles = []string{
+	"README*", "LICENSE*", "CHANGELOG*", "CONTRIBUTING*", "CODE_OF_CONDUCT*", "SECURITY*",
+	"preflight.yml", "Makefile", "Dockerfile", "netlify.toml", "vercel.json",
+}
+
+// buildFreshnessSlack absorbs the gap between a build starting (reading
+// sources) and finishing (writing the last output file).
+const buildFreshnessSlack = time.Minute
+
+// BuildFreshnessCheck warns when a build output directory (dist/, _site/,
+// public/, build/) is older than the sources it's generated from, i.e.
+// deploying it would ship a stale build. Committed output is compared by
+// git commit time, since a checkout resets file mtimes; untracked output
+// by file mtime.
+type BuildFreshnessCheck struct{}
+
+func (c BuildFreshnessCheck) ID() string {
+	return "build_freshness"
+}
+
+func (c BuildFreshnessCheck) Title() string {
+	return "Build artifact freshness"
+}
+
+func (c BuildFreshnessCheck) Run(ctx Context) (CheckResult, error) {
+	var targets []buildTarget
+	targets = append(targets, stackBuildTargets[ctx.Config.Stack]...)
+	targets = append(targets, genericBuildTargets...)
+
+	var checked []string
+	var stale []string
+	var details []string
+	seen := make(map[string]bool)
+	for _, t := range targets {
+		if seen[t.output] {
+			continue
+		}
+		seen[t.output] = true
+		if info, err := os.Stat(filepath.Join(ctx.RootDir, t.output)); err != nil || !info.IsDir() {
+			continue
+		}
+
+		sources := existingDirs(ctx.RootDir, t.sources)
+		if len(t.sources) > 0 && len(sources) == 0 {
+			continue
+		}
+
+		built, changed, changedPath, via := buildTimes(ctx.RootDir, t.output, sources)
+		if built.IsZero() || changed.IsZero() {
+			continue
+		}
+		checked = append(checked, t.output+"/")
+		if changed.Sub(built) <= buildFreshnessSlack {
+			continue
+		}
+
+		behind := formatBuildLag(changed.Sub(built))
+		stale = append(stale, fmt.Sprintf("%s/ is %s behind its sources", t.output, behind))
+		detail := fmt.Sprintf("%s/ built %s, sources changed %s (%s)", t.output, built.Format("2006-01-02 15:04"), changed.Format("2006-01-02 15:04"), via)
+		if changedPath != "" {
+			detail += "; newest source: " + changedPath
+		}
+		details = append(details, detail)
+	}
+
+	if len(checked) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No build output directory found, skipping",
+		}, nil
+	}
+
+	if len(stale) > 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  strings.Join(stale, "; "),
+			Suggestions: []string{
+				"Rebuild before deploying so the output matches the current source",
+				"Or build in CI/on the host and stop committing build output (add it to .gitignore)",
+			},
+			Details: details,
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityInfo,
+		Passed:   true,
+		Message:  "Build output is up to date: " + strings.Join(checked, ", "),
+	}, nil
+}
+
+// existingDirs returns the entries of dirs that exist under rootDir.
+func existingDirs(rootDir string, dirs []string) []string {
+	var out []string
+	for _, d := range dirs {
+		if info, err := os.Stat(filepath.Join(rootDir, d)); err == nil && info.IsDir() {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// buildTimes returns when output was last built and when sources (the
+// whole project minus output, dotfiles and buildMetaFiles when empty) last
+// changed. Output tracked in git is compared by commit time; anything else
+// by file mtime.
+func buildTimes(rootDir, output string, sources []string) (built, changed time.Time, changedPath, via string) {
+	if tracked, _ := runGit(rootDir, "ls-files", "--", output); strings.TrimSpace(tracked) != "" {
+		built = gitLastCommitTime(rootDir, output)
+		pathspec := sources
+		if len(pathspec) == 0 {
+			pathspec = []string{".", ":(exclude).*"}
+			for _, name := range buildMetaFiles {
+				pathspec = append(pathspec, ":(exclude)"+name)
+			}
+		}
+		pathspec = append(pathspec, ":(exclude)"+output)
+		changed = gitLastCommitTime(rootDir, pathspec...)
+		return built, changed, "", "git commit times"
+	}
+
+	built, _ = newestMtime(rootDir, []string{output}, "")
+	if len(sources) == 0 {
+		sources = []string{"."}
+	}
+	changed, changedPath = newestMtime(rootDir, sources, output)
+	return built, changed, changedPath, "file mtimes"
+}
+
+// isBuildMetaFile reports whether name, a file directly under the project
+// root, is one of buildMetaFiles.
+func isBuildMetaFile(name string) bool {
+	for _, pattern := range buildMetaFiles {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func gitLastCommitTime(rootDir string, pathspec ...string) time.Time {
+	out, err := runGit(rootDir, append([]string{"log", "-1", "--format=%cI", "--"}, pathspec...)...)
+	if err != nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(out))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// newestMtime returns the most recent modification time (and its
+// project-relative path) of any file under dirs, skipping the exclude
+// directory, dependency/cache directories, dotfiles and top-level
+// buildMetaFiles.
+func newestMtime(rootDir string, dirs []string, exclude string) (time.Time, string) {
+	skipDirs := map[string]bool{
+		"node_modules": true,
+		"vendor":       true,
+		".git":         true,
+		".next":        true,
+		".nuxt":        true,
+		".astro":       true,
+		".cache":       true,
+		"coverage":     true,
+		"__pycache__":  true,
+		".turbo":       true,
+		".vercel":      true,
+		".netlify":     true,
+		"resources":    true, // Hugo's generated asset cache
+	}
+	excludePath := ""
+	if exclude != "" {
+		excludePath = filepath.Join(rootDir, exclude)
+	}
+
+	var newest time.Time
+	var newestPath string
+	for _, dir := range dirs {
+		_ = walkDir(filepath.Join(rootDir, dir), func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				if d != nil && d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				if path == excludePath || (path != filepath.Join(rootDir, dir) && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), "."))) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+				return nil
+			}
+			if filepath.Dir(path) == rootDir && isBuildMetaFile(d.Name()) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if info.ModTime().After(newest) {
+				newest = info.ModTime()
+				newestPath = relPath(rootDir, path)
+			}
+			return nil
+		})
+	}
+	return newest, newestPath
+}
+
+func formatBuildLag(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	}
+}
@@ -0,0 +1,91 @@
+package checks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+)
+
+func TestBuildFreshnessCheck(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name  string
+		stack string
+		files map[string]time.Time
+		want  bool
+	}{
+		{"no build output", "node", map[string]time.Time{"src/index.ts": now}, true},
+		{"fresh dist", "node", map[string]time.Time{
+			"src/index.ts":  now.Add(-time.Hour),
+			"dist/index.js": now,
+		}, true},
+		{"stale dist", "node", map[string]time.Time{
+			"src/index.ts":  now,
+			"dist/index.js": now.Add(-72 * time.Hour),
+		}, false},
+		{"within slack", "node", map[string]time.Time{
+			"src/index.ts":  now,
+			"dist/index.js": now.Add(-30 * time.Second),
+		}, true},
+		{"stale hugo public", "hugo", map[string]time.Time{
+			"content/post.md":   now,
+			"public/index.html": now.Add(-24 * time.Hour),
+		}, false},
+		{"public is a source dir outside hugo", "next", map[string]time.Time{
+			"app/page.tsx":      now,
+			"public/robots.txt": now.Add(-24 * time.Hour),
+		}, true},
+		{"jekyll compares the whole project", "jekyll", map[string]time.Time{
+			"_posts/hello.md":  now,
+			"_site/index.html": now.Add(-24 * time.Hour),
+		}, false},
+		{"jekyll ignores repo docs and config", "jekyll", map[string]time.Time{
+			"_posts/hello.md":  now.Add(-48 * time.Hour),
+			"_site/index.html": now.Add(-24 * time.Hour),
+			"README.md":        now,
+			"preflight.yml":    now,
+		}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := checktest.NewProject(t, nil)
+			for name, mtime := range tc.files {
+				p.WriteFile(name, "x")
+				p.SetModTime(name, mtime)
+			}
+			p.Config.Stack = tc.stack
+			res := p.Run(checks.BuildFreshnessCheck{})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q, %v)", res.Passed, tc.want, res.Message, res.Details)
+			}
+		})
+	}
+}
+
+func TestBuildFreshnessGitTrackedOutput(t *testing.T) {
+	p := checktest.NewProject(t, map[string]string{
+		"_posts/hello.md":  "hello",
+		"_site/index.html": "hello",
+	})
+	p.InitGit()
+	p.Config.Stack = "eleventy"
+	day := time.Now().Add(-72 * time.Hour)
+	p.Commit(day)
+
+	// Committing repo docs and config after the build leaves it fresh.
+	p.WriteFile("README.md", "# site")
+	p.WriteFile("preflight.yml", "projectName: site")
+	p.Commit(day.Add(24 * time.Hour))
+	if res := p.Run(checks.BuildFreshnessCheck{}); !res.Passed {
+		t.Fatalf("README/preflight.yml commit marked the build stale: %q", res.Message)
+	}
+
+	// A content commit does.
+	p.WriteFile("_posts/hello.md", "hello again")
+	p.Commit(day.Add(48 * time.Hour))
+	if res := p.Run(checks.BuildFreshnessCheck{}); res.Passed {
+		t.Fatal("expected a stale build after a source commit")
+	}
+}
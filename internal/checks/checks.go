@@ -69,6 +69,14 @@ type Context struct {
 	// preferred). Convenience for env-agnostic checks like favicon
 	// detection that don't care which environment the markup came from.
 	PageHTML string
+	// Resolver answers the DNS lookups of DNS-based checks (email auth,
+	// IPv6). Nil means the system resolver; a scan sets a DoHResolver
+	// when preflight.yml asks for one.
+	Resolver netutil.Resolver
+	// UserAgent is what checks that build their own HTTP client (see
+	// netutil.Polite) identify as. Client already sets it; empty leaves
+	// Go's default.
+	UserAgent string
 }
 
 // reqContext returns ctx.Ctx if set, otherwise context.Background(). Lets
@@ -90,6 +98,8 @@ type Check interface {
 // Registry of all available checks
 var Registry = []Check{
 	EnvParityCheck{},
+	PlatformEnvCheck{},
+	SecretsManagerCheck{},
 	HealthCheck{},
 	StripeWebhookCheck{},
 	SentryCheck{},
@@ -115,13 +125,26 @@ var Registry = []Check{
 	ViewportCheck{},
 	LangAttributeCheck{},
 	DebugStatementsCheck{},
+	DeprecatedServicesCheck{},
+	DeadCodeCheck{},
+	BuildFreshnessCheck{},
 	StructuredDataCheck{},
 	ImageOptimizationCheck{},
 	EmailAuthCheck{},
 	HumansTxtCheck{},
 	WWWRedirectCheck{},
+	IPv6Check{},
+	MultiRegionCheck{},
 	LegalPagesCheck{},
+	LegalPlaceholdersCheck{},
+	LegalFreshnessCheck{},
+	ConsentModeCheck{},
+	ConsentBannerCheck{},
+	DoNotSellCheck{},
+	ImpressumCheck{},
+	AgeGateCheck{},
 	IndexNowCheck{},
+	EmailObfuscationCheck{},
 	// Cookie Consent checks
 	CookieConsentJSCheck,
 	CookiebotCheck{},
@@ -313,8 +336,8 @@ func FetchPageHTML(ctx context.Context, client *http.Client, rawURL string) stri
 	return string(body)
 }
 
-// doGet performs an HTTP GET with a User-Agent header. A nil ctx is
-// treated as context.Background().
+// doGet performs an HTTP GET. The scan's client sets the User-Agent. A nil
+// ctx is treated as context.Background().
 func doGet(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -323,7 +346,6 @@ func doGet(ctx context.Context, client *http.Client, url string) (*http.Response
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Preflight/1.0")
 	return client.Do(req)
 }
 
@@ -383,7 +405,8 @@ func tryURL(ctx context.Context, client *http.Client, url string) (*http.Respons
 
 // Comment-stripping regexes, compiled once at package init.
 var (
-	reSingleLineComment = regexp.MustCompile(`//[^\n]*`)
+	// A // preceded by ":" is a URL scheme, not a comment.
+	reSingleLineComment = regexp.MustCompile(`(^|[^:])//[^\n]*`)
 	reMultiLineComment  = regexp.MustCompile(`(?s)/\*.*?\*/`)
 	reHTMLComment       = regexp.MustCompile(`(?s)<!--.*?-->`)
 	reTwigComment       = regexp.MustCompile(`(?s)\{#.*?#\}`)
@@ -405,7 +428,7 @@ func stripComments(content string) string {
 // which makes it safer for content that legitimately uses `#` at line
 // starts (CSS selectors, YAML keys, etc.).
 func stripCodeComments(content string) string {
-	content = reSingleLineComment.ReplaceAllString(content, "")
+	content = reSingleLineComment.ReplaceAllString(content, "$1")
 	content = reMultiLineComment.ReplaceAllString(content, "")
 	content = reHTMLComment.ReplaceAllString(content, "")
 	content = reTwigComment.ReplaceAllString(content, "")
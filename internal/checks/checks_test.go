@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -256,3 +257,53 @@ func TestRunPerEnv(t *testing.T) {
 		}
 	})
 }
+
+// TestRegistryDeterministic runs every registered check repeatedly against
+// the same project and requires identical results, so map iteration or
+// walk order can't reorder findings between scans (which breaks diffs and
+// snapshot tests of JSON reports).
+func TestRegistryDeterministic(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		".env":         "ZETA=1\nALPHA=2\nMIKE=3\nSECRET_ONLY_HERE=4\nANOTHER=5\n",
+		".env.example": "DELTA=\nBRAVO=\nECHO=\nCHARLIE=\n",
+		"index.html":   "<html><head></head><body>console.log('x')</body></html>",
+		"src/app.js":   "console.log('debug');\n// TODO remove\n",
+		"package.json": `{"name":"x","dependencies":{"stripe":"1.0.0"}}`,
+	})
+	cfg := &config.PreflightConfig{
+		ProjectName: "x",
+		Stack:       "static",
+		Checks: config.ChecksConfig{
+			EnvParity: &config.EnvParityConfig{Enabled: true, EnvFile: ".env", ExampleFile: ".env.example"},
+			SEOMeta:   &config.SEOMetaConfig{Enabled: true, MainLayout: "index.html"},
+		},
+	}
+	ctx := Context{Ctx: context.Background(), RootDir: root, Config: cfg}
+
+	for _, c := range Registry {
+		first, firstErr := c.Run(ctx)
+		for i := 0; i < 5; i++ {
+			again, err := c.Run(ctx)
+			if (err == nil) != (firstErr == nil) || !reflect.DeepEqual(first, again) {
+				t.Errorf("%s: results differ between runs:\n%+v\n%+v", c.ID(), first, again)
+				break
+			}
+		}
+	}
+}
+
+// TestConfigReferenceCheckIDs keeps the checks tags in config (shown by
+// `preflight config docs`) in step with the registry.
+func TestConfigReferenceCheckIDs(t *testing.T) {
+	known := make(map[string]bool)
+	for _, c := range Registry {
+		known[c.ID()] = true
+	}
+	for _, d := range config.Reference() {
+		for _, id := range d.Checks {
+			if !known[id] {
+				t.Errorf("%s: check %q is not in Registry", d.Key, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package checktest helps test checks: it builds throwaway fixture
+// projects, runs a check against them the way `preflight scan` does, and
+// compares the result with a golden file.
+//
+// Tests that use it live in package checks_test (checktest imports
+// checks, so package checks' own tests can't). A typical test:
+//
+//	p := checktest.NewProject(t, map[string]string{"index.html": "<html></html>"})
+//	res := p.Run(checks.SEOMetadataCheck{})
+//	p.AssertGolden("seo_meta_missing", res)
+//
+// Run `go test ./internal/checks/... -update` to rewrite golden files
+// after an intended change to a check's output.
+package checktest
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files under testdata/golden")
+
+// RootPlaceholder replaces a Project's root directory in golden files,
+// which would otherwise differ on every run.
+const RootPlaceholder = "$ROOT"
+
+// Project is a fixture project in a temporary directory.
+type Project struct {
+	t testing.TB
+	// Root is the project directory.
+	Root string
+	// Config is passed to checks as-is; tests set the stack, URLs and
+	// per-check config on it before calling Run.
+	Config *config.PreflightConfig
+}
+
+// NewProject creates a project containing files (project-relative path
+// to content) with an empty config.
+func NewProject(t testing.TB, files map[string]string) *Project {
+	t.Helper()
+	p := &Project{t: t, Root: t.TempDir(), Config: &config.PreflightConfig{}}
+	for rel, content := range files {
+		p.WriteFile(rel, content)
+	}
+	return p
+}
+
+// WriteFile writes content to the project-relative path rel, creating
+// parent directories as needed.
+func (p *Project) WriteFile(rel, content string) {
+	p.t.Helper()
+	full := filepath.Join(p.Root, rel)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		p.t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		p.t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+// SetModTime sets the modification time of rel, for checks that compare
+// file ages.
+func (p *Project) SetModTime(rel string, mtime time.Time) {
+	p.t.Helper()
+	if err := os.Chtimes(filepath.Join(p.Root, rel), mtime, mtime); err != nil {
+		p.t.Fatalf("chtimes %s: %v", rel, err)
+	}
+}
+
+// InitGit makes the project a git work tree with a fixed identity, so
+// commits don't depend on the host's git config. It skips the test when
+// git isn't installed.
+func (p *Project) InitGit() {
+	p.t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		p.t.Skip("git not installed")
+	}
+	p.Git("init")
+	p.Git("config", "user.email", "test@example.com")
+	p.Git("config", "user.name", "Test")
+}
+
+// Git runs git in the project and returns its output, failing the test
+// on error.
+func (p *Project) Git(args ...string) string {
+	p.t.Helper()
+	return p.git(nil, args...)
+}
+
+func (p *Project) git(env []string, args ...string) string {
+	p.t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", p.Root}, args...)...)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		p.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+// Commit stages paths (everything when none are given) and commits them.
+// A non-zero when sets both the author and committer date.
+func (p *Project) Commit(when time.Time, paths ...string) {
+	p.t.Helper()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	p.Git(append([]string{"add", "--"}, paths...)...)
+
+	var env []string
+	if !when.IsZero() {
+		date := when.Format(time.RFC3339)
+		env = []string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date}
+	}
+	p.git(env, "commit", "-q", "-m", "fixture")
+}
+
+// Context returns the checks.Context a scan of the project would use,
+// without an HTTP client or rendered pages. Set Client, PageHTML etc. on
+// the result for checks that need them.
+func (p *Project) Context() checks.Context {
+	return checks.Context{Ctx: context.Background(), RootDir: p.Root, Config: p.Config}
+}
+
+// Run runs c against the project. See Run.
+func (p *Project) Run(c checks.Check) checks.CheckResult {
+	p.t.Helper()
+	return Run(p.t, c, p.Context())
+}
+
+// Run runs c with ctx, failing the test if the check returns an error or
+// a result that doesn't carry its own ID and title (the runner relies on
+// both to group and ignore results).
+func Run(t testing.TB, c checks.Check, ctx checks.Context) checks.CheckResult {
+	t.Helper()
+	res, err := c.Run(ctx)
+	if err != nil {
+		t.Fatalf("%s: Run returned error: %v", c.ID(), err)
+	}
+	if res.ID != c.ID() {
+		t.Errorf("%s: result ID = %q, want the check's ID", c.ID(), res.ID)
+	}
+	if res.Title != c.Title() {
+		t.Errorf("%s: result Title = %q, want %q", c.ID(), res.Title, c.Title())
+	}
+	return res
+}
+
+// AssertGolden compares got with testdata/golden/<name>.json, after
+// replacing the project root with RootPlaceholder.
+func (p *Project) AssertGolden(name string, got checks.CheckResult) {
+	p.t.Helper()
+	AssertGolden(p.t, name, scrub(got, p.Root))
+}
+
+// AssertGolden compares got with testdata/golden/<name>.json in the
+// calling package's directory. With -update it writes the file instead.
+func AssertGolden(t testing.TB, name string, got checks.CheckResult) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	data = append(data, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create it): %v", err)
+	}
+	if string(want) != string(data) {
+		t.Errorf("%s doesn't match (run with -update if the change is intended)\n--- want\n%s--- got\n%s", path, want, data)
+	}
+}
+
+// scrub replaces root in every string of r.
+func scrub(r checks.CheckResult, root string) checks.CheckResult {
+	replace := func(s string) string {
+		s = strings.ReplaceAll(s, root+string(filepath.Separator), RootPlaceholder+"/")
+		return strings.ReplaceAll(s, root, RootPlaceholder)
+	}
+	r.Message = replace(r.Message)
+	r.Suggestions = replaceAll(r.Suggestions, replace)
+	r.Details = replaceAll(r.Details, replace)
+	return r
+}
+
+func replaceAll(in []string, f func(string) string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = f(s)
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package checktest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/checks"
+)
+
+// fileCountCheck is a stand-in for a plugin check: it reports how many
+// .html files the project has, naming each by its full path.
+type fileCountCheck struct{}
+
+func (fileCountCheck) ID() string    { return "fileCount" }
+func (fileCountCheck) Title() string { return "HTML files" }
+
+func (c fileCountCheck) Run(ctx checks.Context) (checks.CheckResult, error) {
+	matches, _ := filepath.Glob(filepath.Join(ctx.RootDir, "*.html"))
+	return checks.CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: checks.SeverityInfo,
+		Passed:   len(matches) > 0,
+		Message:  strings.Join(matches, ", "),
+	}, nil
+}
+
+func TestProjectRunAndGolden(t *testing.T) {
+	p := NewProject(t, map[string]string{
+		"index.html":     "<html></html>",
+		"about.html":     "<html></html>",
+		"assets/app.css": "body{}",
+	})
+	res := p.Run(fileCountCheck{})
+	if !res.Passed {
+		t.Fatalf("expected pass, got %q", res.Message)
+	}
+	if got := scrub(res, p.Root).Message; got != "$ROOT/about.html, $ROOT/index.html" {
+		t.Errorf("scrubbed Message = %q", got)
+	}
+	p.AssertGolden("file_count", res)
+}
+
+func TestProjectSetModTime(t *testing.T) {
+	p := NewProject(t, map[string]string{"dist/app.js": "x"})
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	p.SetModTime("dist/app.js", when)
+	info, err := os.Stat(filepath.Join(p.Root, "dist/app.js"))
+	if err != nil || !info.ModTime().Equal(when) {
+		t.Errorf("ModTime = %v (%v), want %v", info.ModTime(), err, when)
+	}
+}
+
+func TestProjectCommit(t *testing.T) {
+	p := NewProject(t, map[string]string{"index.html": "<html></html>"})
+	p.InitGit()
+	when := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	p.Commit(when)
+	got := strings.TrimSpace(p.Git("log", "-1", "--format=%cI"))
+	if got != when.Format(time.RFC3339) && got != "2021-06-01T12:00:00+00:00" {
+		t.Errorf("commit date = %q, want %s", got, when.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,160 @@
+package checks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+const renderedWithViewportAndLang = `<!doctype html>
+<html dir="ltr" lang="en-US">
+<head><meta name="viewport" content="width=device-width, initial-scale=1.0"></head>
+<body></body></html>`
+
+// A Craft layout whose <html lang> and viewport live in an unconventional
+// partial (header.twig) the static scanner doesn't know about — so the layout
+// itself carries neither tag. Mirrors the joncphillips.com false positive.
+const craftLayoutNoTags = `{% extends "_partials/header.twig" %}
+{% block content %}<h1>Hi</h1>{% endblock %}`
+
+func TestViewportRenderedHTMLFallback(t *testing.T) {
+	p := checktest.NewProject(t, map[string]string{
+		"templates/_layout.twig": craftLayoutNoTags,
+	})
+	p.Config.Stack = "craft"
+
+	t.Run("passes from rendered prod HTML when static scan misses it", func(t *testing.T) {
+		p.Config.URLs = config.URLConfig{Production: "https://prod", Staging: "https://staging"}
+		ctx := p.Context()
+		ctx.PageHTMLProduction = renderedWithViewportAndLang
+		ctx.PageHTMLStaging = renderedWithViewportAndLang
+		res := checktest.Run(t, checks.ViewportCheck{}, ctx)
+		if !res.Passed {
+			t.Fatalf("viewport should pass via rendered HTML; got WARN %q", res.Message)
+		}
+		if !strings.Contains(res.Message, "prod: ✓") {
+			t.Fatalf("expected per-env breakdown, got %q", res.Message)
+		}
+	})
+
+	t.Run("still warns offline when no URL is configured", func(t *testing.T) {
+		p.Config.URLs = config.URLConfig{}
+		res := p.Run(checks.ViewportCheck{})
+		if res.Passed {
+			t.Fatal("viewport should warn offline when the tag is in an unscanned partial")
+		}
+	})
+}
+
+func TestLangRenderedHTMLFallback(t *testing.T) {
+	p := checktest.NewProject(t, map[string]string{
+		"templates/_layout.twig": craftLayoutNoTags,
+	})
+	p.Config.Stack = "craft"
+
+	t.Run("passes from rendered prod HTML when static scan misses it", func(t *testing.T) {
+		p.Config.URLs = config.URLConfig{Production: "https://prod"}
+		ctx := p.Context()
+		ctx.PageHTMLProduction = renderedWithViewportAndLang
+		res := checktest.Run(t, checks.LangAttributeCheck{}, ctx)
+		if !res.Passed {
+			t.Fatalf("lang should pass via rendered HTML; got WARN %q", res.Message)
+		}
+		if !strings.Contains(res.Message, "prod: ✓") {
+			t.Fatalf("expected per-env breakdown, got %q", res.Message)
+		}
+	})
+
+	t.Run("still warns offline when no URL is configured", func(t *testing.T) {
+		p.Config.URLs = config.URLConfig{}
+		res := p.Run(checks.LangAttributeCheck{})
+		if res.Passed {
+			t.Fatal("lang should warn offline when the attribute is in an unscanned partial")
+		}
+	})
+}
+
+func TestAWSSESPassesOnEnvReference(t *testing.T) {
+	p := checktest.NewProject(t, map[string]string{
+		"config/project/project.yaml": "mailer:\n  transportType: putyourlightson\\amazonses\\mail\\AmazonSesAdapter\n  transportSettings:\n    apiKey: $AWS_SES_API_KEY\n    apiSecret: $AWS_SES_API_SECRET\n    region: $AWS_SES_REGION\n",
+	})
+	p.Config.Stack = "craft"
+	p.Config.Services = map[string]config.ServiceConfig{"aws_ses": {Declared: true}}
+	res := p.Run(checks.AWSSESCheck{})
+	if !res.Passed {
+		t.Fatalf("AWS SES should pass when configured via env reference; got WARN %q", res.Message)
+	}
+
+	// Negative: declared but no reference, no .env, no SDK code → still warns.
+	bare := checktest.NewProject(t, map[string]string{"composer.json": "{}"})
+	bare.Config = p.Config
+	if res := bare.Run(checks.AWSSESCheck{}); res.Passed {
+		t.Fatal("AWS SES should warn when declared with no config evidence at all")
+	}
+}
+
+func TestServiceDetectedFromDependencyManifest(t *testing.T) {
+	t.Run("aws ses craft plugin in composer.json", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"composer.json": `{"require":{"putyourlightson/craft-amazon-ses":"3.1.0"}}`,
+		})
+		p.Config.Stack = "craft"
+		p.Config.Services = map[string]config.ServiceConfig{"aws_ses": {Declared: true}}
+		res := p.Run(checks.AWSSESCheck{})
+		if !res.Passed {
+			t.Fatalf("AWS SES should pass when the plugin is a composer dependency; got WARN %q", res.Message)
+		}
+	})
+
+	t.Run("sendgrid npm package in package.json", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"package.json": `{"dependencies":{"@sendgrid/mail":"^8.0.0"}}`,
+		})
+		p.Config.Stack = "node"
+		p.Config.Services = map[string]config.ServiceConfig{"sendgrid": {Declared: true}}
+		res := p.Run(checks.SendGridCheck{})
+		if !res.Passed {
+			t.Fatalf("SendGrid should pass when @sendgrid/mail is a dependency; got WARN %q", res.Message)
+		}
+	})
+}
+
+func TestStructuredDataPerEnvFromRenderedHTML(t *testing.T) {
+	const ldHTML = `<!doctype html><html><head>` +
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"WebSite"}</script>` +
+		`</head><body></body></html>`
+
+	// A template carrying a static SEOmatic reference would short-circuit the
+	// old code before the per-env check; the JSON-LD must still report per-env.
+	// head.twig is on the structured-data partials list, so static analysis can
+	// still find it offline.
+	p := checktest.NewProject(t, map[string]string{
+		"templates/_partials/head.twig": `{{ craft.seomatic.jsonLd }}`,
+	})
+	p.Config.Stack = "craft"
+
+	t.Run("reports per-env when rendered HTML has JSON-LD", func(t *testing.T) {
+		p.Config.URLs = config.URLConfig{Production: "https://prod", Staging: "https://staging"}
+		ctx := p.Context()
+		ctx.PageHTMLProduction = ldHTML
+		ctx.PageHTMLStaging = ldHTML
+		res := checktest.Run(t, checks.StructuredDataCheck{}, ctx)
+		if !res.Passed {
+			t.Fatalf("structured data should pass; got WARN %q", res.Message)
+		}
+		if !strings.Contains(res.Message, "prod: ✓") {
+			t.Fatalf("expected per-env breakdown, got %q", res.Message)
+		}
+	})
+
+	t.Run("falls back to static analysis offline", func(t *testing.T) {
+		p.Config.URLs = config.URLConfig{}
+		res := p.Run(checks.StructuredDataCheck{})
+		if !res.Passed {
+			t.Fatalf("structured data should pass via static SEOmatic reference offline; got WARN %q", res.Message)
+		}
+	})
+}
@@ -0,0 +1,354 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+// regionChecks maps each compliance region (compliance.regions in
+// preflight.yml) to the legal checks it requires. This is the single place
+// that decides which legal checks a region turns on; add new region-specific
+// checks here rather than gating them individually in cmd/scan.go.
+var regionChecks = map[string][]Check{
+	config.RegionEU:   {ConsentBannerCheck{}, ConsentModeCheck{}},
+	config.RegionUK:   {ConsentBannerCheck{}, ConsentModeCheck{}},
+	config.RegionUSCA: {DoNotSellCheck{}},
+	config.RegionDACH: {ImpressumCheck{}},
+}
+
+// ComplianceChecks returns the legal checks required by the configured
+// compliance regions and vertical, plus any region-specific check opted
+// into directly under checks:, de-duplicated (eu and uk share checks) and in Registry
+// order so output is stable regardless of region order.
+func ComplianceChecks(cfg *config.PreflightConfig) []Check {
+	wanted := make(map[string]bool)
+	for _, region := range cfg.Compliance.Regions {
+		for _, c := range regionChecks[region] {
+			wanted[c.ID()] = true
+		}
+	}
+	if cfg.Checks.ConsentMode != nil && cfg.Checks.ConsentMode.Enabled {
+		wanted[ConsentModeCheck{}.ID()] = true
+	}
+	if cfg.Compliance.Vertical != "" {
+		wanted[AgeGateCheck{}.ID()] = true
+	}
+	var out []Check
+	for _, c := range Registry {
+		if wanted[c.ID()] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// trackerServices are declared services that set tracking cookies or send
+// personal data to a third party, and so need consent / opt-out handling.
+// Cookieless analytics (Plausible, Fathom, Umami, …) are deliberately absent.
+var trackerServices = []string{
+	"google_analytics", "posthog", "mixpanel", "amplitude", "segment", "hotjar", "logrocket",
+}
+
+// trackerPatterns fingerprint ad and analytics trackers that are commonly
+// embedded without being declared as a service.
+var trackerPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"Google tag", regexp.MustCompile(`googletagmanager\.com|google-analytics\.com|googleadservices\.com`)},
+	{"Meta Pixel", regexp.MustCompile(`connect\.facebook\.net|fbq\(`)},
+	{"TikTok Pixel", regexp.MustCompile(`analytics\.tiktok\.com`)},
+	{"LinkedIn Insight", regexp.MustCompile(`snap\.licdn\.com`)},
+	{"Hotjar", regexp.MustCompile(`static\.hotjar\.com`)},
+}
+
+// detectTrackers returns the names of ad/analytics trackers found via
+// declared services, the codebase, or the rendered homepage, in a fixed
+// order (declared services first, then trackerPatterns).
+func detectTrackers(ctx Context) []string {
+	page := parseRenderedHTML(ctx.PageHTML)
+	var found []string
+	for _, svc := range trackerServices {
+		if ctx.Config.Services[svc].Declared {
+			found = append(found, svc)
+		}
+	}
+	for _, t := range trackerPatterns {
+		if page.loadsScript(t.pattern) || searchForPatterns(ctx.RootDir, ctx.Config.Stack, []*regexp.Regexp{t.pattern}) {
+			found = append(found, t.name)
+		}
+	}
+	return found
+}
+
+// consentBannerPatterns fingerprint consent management platforms, whether or
+// not they are declared as a service in preflight.yml.
+var consentBannerPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)cookieconsent`),
+	regexp.MustCompile(`(?i)consent\.cookiebot\.com`),
+	regexp.MustCompile(`(?i)cdn\.cookielaw\.org|onetrust`),
+	regexp.MustCompile(`(?i)app\.termly\.io`),
+	regexp.MustCompile(`(?i)cdn-cookieyes\.com`),
+	regexp.MustCompile(`(?i)iubenda\.com`),
+	regexp.MustCompile(`(?i)usercentrics`),
+	regexp.MustCompile(`(?i)didomi`),
+	regexp.MustCompile(`(?i)osano\.com`),
+	regexp.MustCompile(`(?i)klaro`),
+	regexp.MustCompile(`(?i)complianz`),
+	regexp.MustCompile(`(?i)quantcast\.mgr\.consensu\.org|choice\.quantcast`),
+}
+
+// consentServices are the cookie consent service IDs from serviceChecks.
+var consentServices = []string{"cookieconsent", "cookiebot", "onetrust", "termly", "cookieyes", "iubenda"}
+
+// ConsentBannerCheck verifies a cookie consent solution is present when the
+// site embeds trackers and serves EU (GDPR/ePrivacy) or UK (PECR) visitors.
+type ConsentBannerCheck struct{}
+
+func (c ConsentBannerCheck) ID() string {
+	return "consent_banner"
+}
+
+func (c ConsentBannerCheck) Title() string {
+	return "Cookie consent banner"
+}
+
+func (c ConsentBannerCheck) Run(ctx Context) (CheckResult, error) {
+	trackers := detectTrackers(ctx)
+	if len(trackers) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No trackers detected, consent banner not required",
+		}, nil
+	}
+
+	for _, svc := range consentServices {
+		if ctx.Config.Services[svc].Declared {
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityInfo,
+				Passed:   true,
+				Message:  "Consent handled by " + svc,
+			}, nil
+		}
+	}
+	page := parseRenderedHTML(ctx.PageHTML)
+	for _, p := range consentBannerPatterns {
+		if page.loadsScript(p) {
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityInfo,
+				Passed:   true,
+				Message:  "Consent banner found on live site",
+			}, nil
+		}
+	}
+	if location, ok := findPatternLocation(ctx.RootDir, ctx.Config.Stack, consentBannerPatterns); ok {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Consent banner found in " + location,
+		}, nil
+	}
+
+	var regimes []string
+	if ctx.Config.Compliance.HasRegion(config.RegionEU) {
+		regimes = append(regimes, "GDPR/ePrivacy (EU)")
+	}
+	if ctx.Config.Compliance.HasRegion(config.RegionUK) {
+		regimes = append(regimes, "PECR (UK)")
+	}
+	suggestions := []string{
+		"Add a consent management platform (Cookiebot, OneTrust, CookieYes, iubenda, or the open-source CookieConsent)",
+		"Block non-essential trackers until the visitor opts in",
+	}
+	if ctx.Config.Compliance.HasRegion(config.RegionUK) {
+		suggestions = append(suggestions, "ICO guidance: 'Reject all' must be as prominent as 'Accept all', and no cookie walls")
+	}
+
+	msg := "Trackers found (" + strings.Join(trackers, ", ") + ") but no consent banner"
+	if len(regimes) > 0 {
+		msg += "; required under " + strings.Join(regimes, ", ")
+	}
+
+	return CheckResult{
+		ID:          c.ID(),
+		Title:       c.Title(),
+		Severity:    SeverityWarn,
+		Passed:      false,
+		Message:     msg,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// doNotSellPatterns match the CCPA/CPRA opt-out link in its statutory wording,
+// the CPRA "Your Privacy Choices" alternative, or common opt-out URLs.
+var doNotSellPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)do\s+not\s+sell`),
+	regexp.MustCompile(`(?i)your\s+privacy\s+choices`),
+	regexp.MustCompile(`(?i)/(do-not-sell|donotsell|ccpa-opt-out|privacy-choices)\b`),
+}
+
+// doNotSellSourcePatterns are doNotSellPatterns for templates and source,
+// where there's no parsed page to pick links from. The wording only counts
+// as the text of a link element (HTML <a> or a framework Link component),
+// and the opt-out URL only as its target, so a privacy policy saying "we do
+// not sell your personal information" isn't mistaken for the link.
+var doNotSellSourcePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)<(a|Link|NuxtLink|router-link)\b[^>]*>(?:[^<]|<(?:span|strong|em|b|i|small)\b[^>]*>)*?(do\s+not\s+sell|your\s+privacy\s+choices)`),
+	regexp.MustCompile(`(?i)\b(href|to)\s*=\s*\{?\s*["'\x60][^"'\x60]*/(do-not-sell|donotsell|ccpa-opt-out|privacy-choices)\b`),
+}
+
+// DoNotSellCheck verifies a "Do Not Sell or Share My Personal Information"
+// link exists when the site embeds ad/analytics trackers and serves
+// California residents (CCPA as amended by CPRA).
+type DoNotSellCheck struct{}
+
+func (c DoNotSellCheck) ID() string {
+	return "do_not_sell"
+}
+
+func (c DoNotSellCheck) Title() string {
+	return "CCPA \"Do Not Sell\" link"
+}
+
+func (c DoNotSellCheck) Run(ctx Context) (CheckResult, error) {
+	trackers := detectTrackers(ctx)
+	if len(trackers) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No trackers detected, opt-out link not required",
+		}, nil
+	}
+
+	page := parseRenderedHTML(ctx.PageHTML)
+	for _, p := range doNotSellPatterns {
+		if page.hasLink(p) {
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityInfo,
+				Passed:   true,
+				Message:  "Opt-out link found on live site",
+			}, nil
+		}
+	}
+	if location, ok := findPatternLocation(ctx.RootDir, ctx.Config.Stack, doNotSellSourcePatterns); ok {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Opt-out link found in " + location,
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityWarn,
+		Passed:   false,
+		Message:  "Trackers found (" + strings.Join(trackers, ", ") + ") but no \"Do Not Sell or Share\" link",
+		Suggestions: []string{
+			"Add a \"Do Not Sell or Share My Personal Information\" (or \"Your Privacy Choices\") link to the site footer",
+			"Point it at a page or CMP dialog that lets California visitors opt out of ad/analytics sharing",
+			"Honor the Global Privacy Control (Sec-GPC) signal as an opt-out",
+		},
+	}, nil
+}
+
+// impressumPageNames are the file and URL path names a legal notice goes
+// by.
+var impressumPageNames = []string{"impressum", "imprint", "legal-notice", "legal_notice", "mentions-legales"}
+
+var (
+	// reImpressumPath matches a link to an Impressum page in source.
+	reImpressumPath = regexp.MustCompile(`(?i)/(impressum|imprint|legal-notice|legal_notice|mentions-legales)\b`)
+	// reImpressumLink matches a rendered link's path or its text.
+	reImpressumLink = regexp.MustCompile(`(?i)/(impressum|imprint|legal-notice|legal_notice|mentions-legales)\b|^(impressum|imprint|legal notice|mentions légales)$`)
+)
+
+// ImpressumCheck verifies a German-style legal notice (Impressum, required by
+// §5 DDG in Germany and equivalents in Austria and Switzerland) exists.
+type ImpressumCheck struct{}
+
+func (c ImpressumCheck) ID() string {
+	return "impressum"
+}
+
+func (c ImpressumCheck) Title() string {
+	return "Impressum (legal notice)"
+}
+
+func (c ImpressumCheck) Run(ctx Context) (CheckResult, error) {
+	// A link on the rendered homepage is the strongest signal: the law
+	// requires the notice to be reachable from every page.
+	if parseRenderedHTML(ctx.PageHTML).hasLink(reImpressumLink) {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Impressum linked on live site",
+		}, nil
+	}
+
+	searchDirs := []string{"", "app", "src/app", "src/pages", "pages", "views", "resources/views", "templates", "content", "public", "static", "web"}
+	for _, dir := range searchDirs {
+		entries, err := os.ReadDir(filepath.Join(ctx.RootDir, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			base := strings.TrimSuffix(name, filepath.Ext(name))
+			for _, p := range impressumPageNames {
+				if base == p || name == p {
+					return CheckResult{
+						ID:       c.ID(),
+						Title:    c.Title(),
+						Severity: SeverityInfo,
+						Passed:   true,
+						Message:  "Impressum found at " + filepath.Join(dir, entry.Name()),
+					}, nil
+				}
+			}
+		}
+	}
+
+	if location, ok := findPatternLocation(ctx.RootDir, ctx.Config.Stack, []*regexp.Regexp{reImpressumPath}); ok {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Impressum linked in " + location,
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityWarn,
+		Passed:   false,
+		Message:  "No Impressum found; required for sites targeting Germany, Austria or Switzerland",
+		Suggestions: []string{
+			"Add an /impressum page with the operator's name, address, contact email and register details",
+			"Link it from the footer of every page",
+		},
+	}, nil
+}
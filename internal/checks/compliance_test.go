@@ -0,0 +1,211 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestComplianceChecks(t *testing.T) {
+	ids := func(cs []Check) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.ID())
+		}
+		return out
+	}
+
+	cfg := &config.PreflightConfig{Compliance: config.ComplianceConfig{Regions: []string{"dach", "uk", "eu"}}}
+	got := ids(ComplianceChecks(cfg))
+	want := []string{"consent_mode", "consent_banner", "impressum"}
+	if len(got) != len(want) {
+		t.Fatalf("ComplianceChecks = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ComplianceChecks = %v, want %v (Registry order, de-duplicated)", got, want)
+		}
+	}
+
+	if got := ComplianceChecks(&config.PreflightConfig{}); len(got) != 0 {
+		t.Errorf("no regions should enable no checks, got %v", ids(got))
+	}
+}
+
+func TestConsentBannerCheck(t *testing.T) {
+	cfg := &config.PreflightConfig{
+		Stack:      "static",
+		Compliance: config.ComplianceConfig{Regions: []string{"eu"}},
+	}
+	tracker := `<script src="https://connect.facebook.net/en_US/fbevents.js"></script>`
+
+	cases := []struct {
+		name   string
+		layout string
+		want   bool
+	}{
+		{"no trackers", `<html></html>`, true},
+		{"tracker without banner", tracker, false},
+		{"tracker with CMP", tracker + `<script src="https://consent.cookiebot.com/uc.js"></script>`, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := writeFiles(t, map[string]string{"index.html": tc.layout})
+			res, _ := ConsentBannerCheck{}.Run(Context{RootDir: root, Config: cfg})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q)", res.Passed, tc.want, res.Message)
+			}
+		})
+	}
+}
+
+func TestConsentBannerCheckLivePage(t *testing.T) {
+	cfg := &config.PreflightConfig{
+		Stack:      "static",
+		Compliance: config.ComplianceConfig{Regions: []string{"eu"}},
+	}
+	root := writeFiles(t, map[string]string{"index.html": "<html></html>"})
+	tracker := `<script src="https://connect.facebook.net/en_US/fbevents.js"></script>`
+
+	cases := []struct {
+		name string
+		page string
+		want bool
+	}{
+		{"CMP script", tracker + `<script src="https://cdn.cookielaw.org/scripttemplates/otSDKStub.js"></script>`, true},
+		{"inline CMP config", tracker + `<script>var klaroConfig = {apps: []};</script>`, true},
+		{"vendor named in text", tracker + `<p>We evaluated Klaro and Didomi.</p>`, false},
+		{"vendor in a class name", tracker + `<div class="onetrust-style-footer">Footer</div>`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, _ := ConsentBannerCheck{}.Run(Context{RootDir: root, Config: cfg, PageHTML: tc.page})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q)", res.Passed, tc.want, res.Message)
+			}
+		})
+	}
+}
+
+func TestImpressumCheck(t *testing.T) {
+	cfg := &config.PreflightConfig{Stack: "next"}
+
+	root := writeFiles(t, map[string]string{"app/impressum/page.tsx": "export default function Page() {}"})
+	if res, _ := (ImpressumCheck{}).Run(Context{RootDir: root, Config: cfg}); !res.Passed {
+		t.Errorf("expected pass for app/impressum, got %q", res.Message)
+	}
+
+	root = writeFiles(t, map[string]string{"app/page.tsx": "export default function Page() {}"})
+	if res, _ := (ImpressumCheck{}).Run(Context{RootDir: root, Config: cfg}); res.Passed {
+		t.Error("expected warning when no Impressum exists")
+	}
+
+	for page, want := range map[string]bool{
+		`<footer><a href="/de/impressum/">Rechtliches</a></footer>`: true,
+		`<footer><a href="/legal">Imprint</a></footer>`:             true,
+		`<p>Our Impressum is coming soon at /impressum.</p>`:        false,
+	} {
+		res, _ := (ImpressumCheck{}).Run(Context{RootDir: root, Config: cfg, PageHTML: page})
+		if res.Passed != want {
+			t.Errorf("live page %s: Passed = %v, want %v", page, res.Passed, want)
+		}
+	}
+}
+
+func TestDoNotSellCheck(t *testing.T) {
+	cfg := &config.PreflightConfig{
+		Stack:    "static",
+		Services: map[string]config.ServiceConfig{"segment": {Declared: true}},
+	}
+
+	root := writeFiles(t, map[string]string{"index.html": `<footer><a href="/privacy">Privacy</a></footer>`})
+	if res, _ := (DoNotSellCheck{}).Run(Context{RootDir: root, Config: cfg}); res.Passed {
+		t.Errorf("expected warning without opt-out link, got %q", res.Message)
+	}
+
+	ctx := Context{RootDir: root, Config: cfg, PageHTML: `<a href="/ccpa">Do Not Sell or Share My Personal Information</a>`}
+	if res, _ := (DoNotSellCheck{}).Run(ctx); !res.Passed {
+		t.Errorf("expected pass from live homepage link, got %q", res.Message)
+	}
+
+	// Prose that happens to use the words isn't an opt-out link.
+	ctx.PageHTML = `<p>We do not sell your data.</p>`
+	if res, _ := (DoNotSellCheck{}).Run(ctx); res.Passed {
+		t.Errorf("expected warning when the words appear only in body text, got %q", res.Message)
+	}
+
+	// The same goes for the codebase: a privacy policy's wording doesn't
+	// count, a footer link (by text or by URL) does.
+	ctx.PageHTML = ""
+	cases := []struct {
+		name string
+		file string
+		want bool
+	}{
+		{"policy prose", `<h2>Sale of data</h2><p>We do not sell your personal information.</p>`, false},
+		{"footer link text", `<footer><a href="/ccpa"><span>Do Not Sell or Share</span> My Personal Information</a></footer>`, true},
+		{"link component URL", `<Link to="/privacy-choices">Privacy choices</Link>`, true},
+	}
+	for _, tc := range cases {
+		ctx.RootDir = writeFiles(t, map[string]string{"index.html": tc.file})
+		if res, _ := (DoNotSellCheck{}).Run(ctx); res.Passed != tc.want {
+			t.Errorf("%s: passed = %v, want %v (%q)", tc.name, res.Passed, tc.want, res.Message)
+		}
+	}
+}
+
+func TestDetectTrackersLivePage(t *testing.T) {
+	root := writeFiles(t, map[string]string{"index.html": "<html></html>"})
+	cfg := &config.PreflightConfig{Stack: "static"}
+
+	cases := []struct {
+		name string
+		page string
+		want []string
+	}{
+		{"external script", `<script async src="https://www.googletagmanager.com/gtag/js?id=G-ABC123"></script>`, []string{"Google tag"}},
+		{"inline snippet", `<script>!function(f,b,e,v,n,t,s){}(window);fbq('init','1');</script>`, []string{"Meta Pixel"}},
+		{"noscript pixel", `<noscript><img src="https://px.ads.linkedin.com/collect" /><img src="https://snap.licdn.com/x.gif"></noscript>`, []string{"LinkedIn Insight"}},
+		{"mentioned in text", `<p>We don't use Hotjar (static.hotjar.com) or connect.facebook.net.</p>`, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := detectTrackers(Context{RootDir: root, Config: cfg, PageHTML: tc.page})
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("detectTrackers = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAgeGateCheck(t *testing.T) {
+	cfg := &config.PreflightConfig{
+		Stack:      "static",
+		URLs:       config.URLConfig{Production: "https://prod"},
+		Compliance: config.ComplianceConfig{Vertical: "alcohol"},
+	}
+	root := writeFiles(t, map[string]string{"index.html": `<html></html>`})
+
+	cases := []struct {
+		name string
+		page string
+		want bool
+	}{
+		{"gate and notice", `<div id="age-gate">Are you 21 or older?</div><footer>Please drink responsibly.</footer>`, true},
+		{"gate without notice", `<div>Are you over 21?</div>`, false},
+		{"over the age of 18", `<p>Are you over the age of 18?</p><footer>Enjoy responsibly</footer>`, true},
+		{"of legal drinking age", `<p>Are you of legal drinking age?</p><footer>Enjoy responsibly</footer>`, true},
+		{"nothing", `<h1>Shop</h1>`, false},
+		{"not an age prompt", `<p>Are you over the moon about our <span class="usage-check storage_check">page-check</span>?</p><footer>Enjoy responsibly</footer>`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := Context{RootDir: root, Config: cfg, PageHTMLProduction: tc.page, PageHTML: tc.page}
+			res, _ := AgeGateCheck{}.Run(ctx)
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q)", res.Passed, tc.want, res.Message)
+			}
+		})
+	}
+}
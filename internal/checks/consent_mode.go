@@ -0,0 +1,132 @@
+package checks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Google tag fingerprints (GA4, Universal Analytics, Google Ads, GTM). When
+// any of these is present, EU/EEA traffic must be covered by Consent Mode.
+var googleTagPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`googletagmanager\.com`),
+	regexp.MustCompile(`google-analytics\.com`),
+	regexp.MustCompile(`googleadservices\.com`),
+	regexp.MustCompile(`gtag\(`),
+	regexp.MustCompile(`\bAW-[0-9]{6,12}\b`), // Google Ads conversion ID
+	reGTMContainerID,
+}
+
+var (
+	// Matches both gtag('consent', 'default', …) and the GTM
+	// dataLayer.push(['consent', 'default', …]) form.
+	reConsentDefault = regexp.MustCompile(`['"]consent['"]\s*,\s*['"]default['"]`)
+	reConsentUpdate  = regexp.MustCompile(`['"]consent['"]\s*,\s*['"]update['"]`)
+	// ad_user_data and ad_personalization are the two signals Consent Mode
+	// v2 added; a v1-only setup sets just ad_storage/analytics_storage.
+	reConsentV2Signal = regexp.MustCompile(`ad_user_data|ad_personalization`)
+)
+
+// consentModeCMPs are declared cookie consent services that implement Consent
+// Mode v2 themselves (default + update calls are emitted by their script).
+var consentModeCMPs = []string{"cookiebot", "onetrust", "cookieyes", "iubenda", "termly"}
+
+// ConsentModeCheck verifies Google Consent Mode v2 is configured whenever
+// Google Analytics / Ads tags are present. Without it, Google drops ad
+// personalization and conversion modelling for EEA traffic.
+type ConsentModeCheck struct{}
+
+func (c ConsentModeCheck) ID() string {
+	return "consent_mode"
+}
+
+func (c ConsentModeCheck) Title() string {
+	return "Google Consent Mode v2"
+}
+
+func (c ConsentModeCheck) Run(ctx Context) (CheckResult, error) {
+	// found reports whether pattern appears in the codebase or the
+	// rendered homepage (tag manager snippets are often injected by a
+	// CMS plugin and never appear in source).
+	var pages []renderedDoc
+	for _, html := range []string{ctx.PageHTMLProduction, ctx.PageHTML} {
+		if html != "" {
+			pages = append(pages, parseRenderedHTML(html))
+		}
+	}
+	found := func(patterns ...*regexp.Regexp) bool {
+		for _, p := range patterns {
+			for _, page := range pages {
+				if page.loadsScript(p) {
+					return true
+				}
+			}
+		}
+		return searchForPatterns(ctx.RootDir, ctx.Config.Stack, patterns)
+	}
+
+	if !found(googleTagPatterns...) {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No Google Analytics/Ads tags found, skipping",
+		}, nil
+	}
+
+	hasDefault := found(reConsentDefault)
+	if !hasDefault {
+		for _, cmp := range consentModeCMPs {
+			if ctx.Config.Services[cmp].Declared {
+				return CheckResult{
+					ID:       c.ID(),
+					Title:    c.Title(),
+					Severity: SeverityInfo,
+					Passed:   true,
+					Message:  "Consent Mode delegated to " + cmp + " (verify Consent Mode v2 is enabled in its settings)",
+				}, nil
+			}
+		}
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  "Google tags found without a Consent Mode default; ad-personalization signals are sent before consent",
+			Suggestions: []string{
+				"Call gtag('consent', 'default', {...}) before the Google tag loads, denying ad_storage, analytics_storage, ad_user_data and ad_personalization",
+				"Call gtag('consent', 'update', {...}) from your cookie banner once the visitor chooses",
+				"Or use a Google-certified CMP (Cookiebot, OneTrust, CookieYes, iubenda) with Consent Mode v2 enabled",
+			},
+		}, nil
+	}
+
+	var missing []string
+	if !found(reConsentV2Signal) {
+		missing = append(missing, "v2 signals (ad_user_data, ad_personalization)")
+	}
+	if !found(reConsentUpdate) {
+		missing = append(missing, "consent 'update' call")
+	}
+	if len(missing) > 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  "Consent Mode default found but missing: " + strings.Join(missing, ", "),
+			Suggestions: []string{
+				"Add ad_user_data and ad_personalization to both the default and update calls (required since March 2024)",
+				"Fire gtag('consent', 'update', {...}) when the visitor accepts or rejects cookies",
+			},
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityInfo,
+		Passed:   true,
+		Message:  "Consent Mode v2 default and update calls found",
+	}, nil
+}
@@ -0,0 +1,36 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestConsentModeCheck(t *testing.T) {
+	const gtagSnippet = `<script async src="https://www.googletagmanager.com/gtag/js?id=G-ABC123XYZ"></script>`
+	cases := []struct {
+		name     string
+		layout   string
+		services map[string]config.ServiceConfig
+		want     bool
+	}{
+		{"no google tags", `<html></html>`, nil, true},
+		{"google tag without consent mode", gtagSnippet, nil, false},
+		{"v1 consent default only", gtagSnippet + `<script>gtag('consent', 'default', {ad_storage: 'denied'}); gtag('consent', 'update', {ad_storage: 'granted'});</script>`, nil, false},
+		{"v2 default and update", gtagSnippet + `<script>gtag('consent', 'default', {ad_storage: 'denied', ad_user_data: 'denied', ad_personalization: 'denied'}); gtag("consent", "update", {ad_user_data: 'granted'});</script>`, nil, true},
+		{"delegated to certified CMP", gtagSnippet, map[string]config.ServiceConfig{"cookiebot": {Declared: true}}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := checktest.NewProject(t, map[string]string{"index.html": tc.layout})
+			p.Config.Stack = "static"
+			p.Config.Services = tc.services
+			res := p.Run(checks.ConsentModeCheck{})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q)", res.Passed, tc.want, res.Message)
+			}
+		})
+	}
+}
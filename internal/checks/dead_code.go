@@ -0,0 +1,205 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+var (
+	// reLeftoverName matches editor/OS/manual backup copies: page-old.tsx,
+	// header_backup.php, about copy.html, index (1).html. "-copy" alone is
+	// left out since it's a common name for marketing-copy components.
+	reLeftoverName = regexp.MustCompile(`(?i)([-_. ](old|backup|bak|orig)|\scopy(\s\d+)?|\s\(\d+\))$`)
+	// reLeftoverExt matches backup extensions, including Vim swap files.
+	reLeftoverExt = regexp.MustCompile(`(?i)\.(bak|orig|old|backup|swp|swo|tmp)$`)
+)
+
+// placeholderRoutePatterns fingerprint a page or handler that was stubbed
+// out and never finished.
+var placeholderRoutePatterns = []struct {
+	label   string
+	pattern *regexp.Regexp
+}{
+	{"\"coming soon\"", regexp.MustCompile(`(?i)\bcoming\s+soon\b`)},
+	{"\"under construction\"", regexp.MustCompile(`(?i)\bunder\s+construction\b`)},
+	{"lorem ipsum", regexp.MustCompile(`(?i)\blorem\s+ipsum\b`)},
+	{"\"not implemented\"", regexp.MustCompile(`(?i)["'\x60>]\s*not\s+(yet\s+)?implemented\b`)},
+	{"placeholder page", regexp.MustCompile(`(?i)\b(this\s+is\s+a\s+)?placeholder\s+(page|content|text)\b`)},
+}
+
+// routeDirs are path segments under which a file is a page or route
+// handler across the supported stacks.
+var routeDirs = map[string]bool{
+	"pages": true, "app": true, "routes": true, "views": true,
+	"templates": true, "content": true, "controllers": true, "handlers": true,
+}
+
+var routeExtensions = map[string]bool{
+	".html": true, ".htm": true, ".php": true, ".twig": true, ".erb": true,
+	".ejs": true, ".hbs": true, ".njk": true, ".liquid": true, ".md": true, ".mdx": true,
+	".vue": true, ".svelte": true, ".astro": true, ".jsx": true, ".tsx": true,
+	".js": true, ".ts": true, ".py": true, ".rb": true, ".go": true, ".tmpl": true, ".gohtml": true,
+}
+
+// DeadCodeCheck is a lightweight heuristic for things that shouldn't ship:
+// pages/route handlers still showing placeholder content, and backup
+// copies of source files (*-old, *-backup, *.bak) left in the tree.
+type DeadCodeCheck struct{}
+
+func (c DeadCodeCheck) ID() string {
+	return "dead_code"
+}
+
+func (c DeadCodeCheck) Title() string {
+	return "Placeholder pages & leftover files"
+}
+
+func (c DeadCodeCheck) Run(ctx Context) (CheckResult, error) {
+	placeholders, leftovers := scanForDeadCode(ctx.RootDir, ctx.Config.Ignore)
+
+	if len(placeholders) == 0 && len(leftovers) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No placeholder pages or leftover backup files found",
+		}, nil
+	}
+
+	var parts []string
+	var suggestions []string
+	if len(placeholders) > 0 {
+		parts = append(parts, fmt.Sprintf("%d placeholder page(s)", len(placeholders)))
+		suggestions = append(suggestions, "Finish or remove routes that still show placeholder content, or unlink them from navigation")
+	}
+	if len(leftovers) > 0 {
+		parts = append(parts, fmt.Sprintf("%d leftover backup file(s)", len(leftovers)))
+		suggestions = append(suggestions, "Delete backup copies (*-old, *-backup, *.bak); git already keeps the history")
+	}
+
+	// Limit findings shown
+	maxFindings := 10
+	findings := append(placeholders, leftovers...)
+	var details []string
+	for i, finding := range findings {
+		if i >= maxFindings {
+			details = append(details, fmt.Sprintf("... and %d more", len(findings)-maxFindings))
+			break
+		}
+		details = append(details, finding)
+	}
+
+	return CheckResult{
+		ID:          c.ID(),
+		Title:       c.Title(),
+		Severity:    SeverityWarn,
+		Passed:      false,
+		Message:     "Found " + strings.Join(parts, " and "),
+		Suggestions: suggestions,
+		Details:     details,
+	}, nil
+}
+
+func scanForDeadCode(rootDir string, ignore []string) (placeholders, leftovers []string) {
+	skipDirs := map[string]bool{
+		"node_modules": true,
+		"vendor":       true,
+		".git":         true,
+		"dist":         true,
+		"build":        true,
+		".next":        true,
+		".nuxt":        true,
+		"coverage":     true,
+		"__pycache__":  true,
+		".cache":       true,
+		"tmp":          true,
+		"log":          true,
+		"logs":         true,
+		"storage":      true,
+		".turbo":       true,
+		".vercel":      true,
+		".netlify":     true,
+		"_site":        true,
+		"out":          true,
+	}
+
+	_ = walkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel := filepath.ToSlash(relPath(rootDir, path))
+		for _, g := range ignore {
+			if ok, _ := doublestar.Match(filepath.ToSlash(g), rel); ok {
+				return nil
+			}
+		}
+
+		name := d.Name()
+		ext := filepath.Ext(name)
+		if reLeftoverExt.MatchString(name) || strings.HasSuffix(name, "~") ||
+			reLeftoverName.MatchString(strings.TrimSuffix(name, ext)) {
+			leftovers = append(leftovers, rel+" - backup copy")
+			return nil
+		}
+
+		if !routeExtensions[strings.ToLower(ext)] || !inRouteDir(rel) {
+			return nil
+		}
+		// Tests and stories legitimately render placeholder text.
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, ".test.") || strings.Contains(lower, ".spec.") ||
+			strings.Contains(lower, ".stories.") || strings.HasSuffix(lower, "_test.go") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || info.Size() > 500*1024 {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		text := stripCodeComments(string(content))
+		for _, p := range placeholderRoutePatterns {
+			if p.pattern.MatchString(text) {
+				placeholders = append(placeholders, rel+" - "+p.label)
+				break
+			}
+		}
+		return nil
+	})
+
+	return placeholders, leftovers
+}
+
+// inRouteDir reports whether the project-relative path sits under one of
+// routeDirs.
+func inRouteDir(rel string) bool {
+	segments := strings.Split(rel, "/")
+	for _, s := range segments[:len(segments)-1] {
+		if routeDirs[s] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+)
+
+func TestDeadCodeCheck(t *testing.T) {
+	cases := []struct {
+		name   string
+		files  map[string]string
+		ignore []string
+		want   bool
+	}{
+		{"clean", map[string]string{
+			"pages/about.tsx":          "export default () => <h1>About</h1>",
+			"components/hero-copy.tsx": "export const HeroCopy = 1",
+			"styles/bold.css":          "b{}",
+		}, nil, true},
+		{"coming soon page", map[string]string{"pages/pricing.tsx": "export default () => <h1>Coming Soon</h1>"}, nil, false},
+		{"placeholder in comment only", map[string]string{"app/blog/page.tsx": "// TODO: coming soon banner\nexport default () => null"}, nil, true},
+		{"placeholder outside route dirs", map[string]string{"components/Banner.tsx": "<p>Coming soon</p>"}, nil, true},
+		{"not implemented handler", map[string]string{"routes/export.js": "res.status(501).send('Not implemented')"}, nil, false},
+		{"old copy", map[string]string{"src/header-old.php": "<?php"}, nil, false},
+		{"bak extension", map[string]string{"config/app.php.bak": "<?php"}, nil, false},
+		{"finder duplicate", map[string]string{"templates/index copy.html": "<html>"}, nil, false},
+		{"ignored", map[string]string{"legacy/page-backup.html": "<html>"}, []string{"legacy/**"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := checktest.NewProject(t, tc.files)
+			p.Config.Ignore = tc.ignore
+			res := p.Run(checks.DeadCodeCheck{})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q, %v)", res.Passed, tc.want, res.Message, res.Details)
+			}
+		})
+	}
+}
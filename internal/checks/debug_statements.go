@@ -290,7 +290,7 @@ func scanForDebugStatements(rootDir string, ignore []string) []string {
 	}
 
 	// Walk the project
-	_ = filepath.WalkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+	_ = walkDir(rootDir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			if d != nil && d.IsDir() {
 				return filepath.SkipDir
@@ -0,0 +1,153 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// deprecatedService describes a third-party service or API that has been
+// shut down. Integrations with these keep compiling and deploying fine but
+// silently do nothing in production, so they're easy to miss.
+type deprecatedService struct {
+	name     string
+	patterns []*regexp.Regexp
+	// files are extra project-relative config files scanned raw, for
+	// services referenced outside the usual source/template extensions
+	// (e.g. Heroku's app.json).
+	files []string
+	// filePatterns are matched against files only. Use them for
+	// fingerprints too generic to search the whole codebase for.
+	filePatterns []*regexp.Regexp
+	suggestion   string
+}
+
+// reUniversalAnalyticsID matches a Universal Analytics property ID
+// (UA-XXXXXX-Y). Shared with the Google Analytics service check.
+var reUniversalAnalyticsID = regexp.MustCompile(`\bUA-[0-9]{4,10}-[0-9]{1,4}\b`)
+
+// deprecatedServices is the embedded list of dead services. Keep it small
+// and high-signal: only add entries whose shutdown is final and whose
+// integration has an unambiguous fingerprint.
+var deprecatedServices = []deprecatedService{
+	{
+		name: "Universal Analytics",
+		patterns: []*regexp.Regexp{
+			reUniversalAnalyticsID,
+			regexp.MustCompile(`google-analytics\.com/analytics\.js`),
+			regexp.MustCompile(`google-analytics\.com/ga\.js`),
+		},
+		suggestion: "Universal Analytics stopped processing data in July 2024; migrate to a GA4 (G-XXXXXXX) property",
+	},
+	{
+		name: "Heroku free dynos/add-ons",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`heroku-(postgresql|redis):hobby-dev`),
+		},
+		files: []string{"app.json", "heroku.yml"},
+		filePatterns: []*regexp.Regexp{
+			regexp.MustCompile(`"size"\s*:\s*"free"`),
+		},
+		suggestion: "Heroku free dynos and hobby-dev add-ons were removed in November 2022; move to an eco/basic plan or another host",
+	},
+	{
+		name: "Twitter API v1.1",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`api\.twitter\.com/1\.1/`),
+			regexp.MustCompile(`upload\.twitter\.com/1\.1/`),
+		},
+		suggestion: "Most Twitter (X) API v1.1 endpoints were retired in 2023; migrate to the X API v2",
+	},
+	{
+		name: "Google+ platform",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`apis\.google\.com/js/plusone\.js`),
+			regexp.MustCompile(`plus\.google\.com/share`),
+		},
+		suggestion: "Google+ was shut down in 2019; remove the +1/share buttons",
+	},
+	{
+		name: "Google URL Shortener API",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`googleapis\.com/urlshortener`),
+		},
+		suggestion: "The goo.gl shortener API was shut down in 2019; use another link shortener",
+	},
+	{
+		name: "Firebase Dynamic Links",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`firebasedynamiclinks\.googleapis\.com`),
+			regexp.MustCompile(`firebase/dynamic-links`),
+			regexp.MustCompile(`firebase_dynamic_links`),
+		},
+		suggestion: "Firebase Dynamic Links was shut down in August 2025; migrate to App Links / Universal Links",
+	},
+}
+
+// DeprecatedServicesCheck warns when the codebase still integrates a
+// third-party service that no longer functions.
+type DeprecatedServicesCheck struct{}
+
+func (c DeprecatedServicesCheck) ID() string {
+	return "deprecated_services"
+}
+
+func (c DeprecatedServicesCheck) Title() string {
+	return "Deprecated services"
+}
+
+func (c DeprecatedServicesCheck) Run(ctx Context) (CheckResult, error) {
+	var found []string
+	var suggestions []string
+	var details []string
+
+	for _, svc := range deprecatedServices {
+		location := findDeprecatedService(ctx, svc)
+		if location == "" {
+			continue
+		}
+		found = append(found, svc.name)
+		suggestions = append(suggestions, svc.suggestion)
+		details = append(details, fmt.Sprintf("%s: %s", svc.name, location))
+	}
+
+	if len(found) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No deprecated services found",
+		}, nil
+	}
+
+	return CheckResult{
+		ID:          c.ID(),
+		Title:       c.Title(),
+		Severity:    SeverityWarn,
+		Passed:      false,
+		Message:     fmt.Sprintf("Still integrates %d shut-down service(s): %s", len(found), strings.Join(found, ", ")),
+		Suggestions: suggestions,
+		Details:     details,
+	}, nil
+}
+
+// findDeprecatedService returns where svc was found (a project-relative
+// path), or "" when it isn't referenced.
+func findDeprecatedService(ctx Context, svc deprecatedService) string {
+	for _, file := range svc.files {
+		content, err := os.ReadFile(filepath.Join(ctx.RootDir, file))
+		if err != nil {
+			continue
+		}
+		for _, pattern := range append(svc.patterns, svc.filePatterns...) {
+			if pattern.Match(content) {
+				return file
+			}
+		}
+	}
+	location, _ := findPatternLocation(ctx.RootDir, ctx.Config.Stack, svc.patterns)
+	return location
+}
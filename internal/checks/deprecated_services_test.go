@@ -0,0 +1,75 @@
+package checks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+)
+
+func TestDeprecatedServicesCheck(t *testing.T) {
+	t.Run("flags Universal Analytics and Heroku free dynos", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"index.html": `<script>ga('create', 'UA-1234567-1', 'auto');</script>`,
+			"app.json":   `{"formation": {"web": {"quantity": 1, "size": "free"}}}`,
+		})
+		p.Config.Stack = "static"
+		res := p.Run(checks.DeprecatedServicesCheck{})
+		if res.Passed {
+			t.Fatal("expected deprecated services to warn")
+		}
+		for _, name := range []string{"Universal Analytics", "Heroku"} {
+			if !strings.Contains(res.Message, name) {
+				t.Errorf("message %q should mention %s", res.Message, name)
+			}
+		}
+	})
+
+	t.Run("passes on GA4-only project", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"index.html": `<script async src="https://www.googletagmanager.com/gtag/js?id=G-ABC123XYZ"></script>`,
+		})
+		p.Config.Stack = "static"
+		res := p.Run(checks.DeprecatedServicesCheck{})
+		if !res.Passed {
+			t.Fatalf("expected pass, got %q", res.Message)
+		}
+	})
+
+	t.Run("matches API URLs in source", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"src/tweet.js": `fetch("https://api.twitter.com/1.1/statuses/update.json", {method: "POST"})`,
+		})
+		p.Config.Stack = "node"
+		res := p.Run(checks.DeprecatedServicesCheck{})
+		if res.Passed || !strings.Contains(res.Message, "Twitter API v1.1") {
+			t.Fatalf("expected Twitter API v1.1 to be flagged, got %q", res.Message)
+		}
+		if len(res.Details) != 1 || !strings.Contains(res.Details[0], "src/tweet.js") {
+			t.Errorf("details = %v, want the matching file", res.Details)
+		}
+	})
+
+	t.Run("ignores a commented-out UA snippet", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"src/analytics.js": "// ga('create', 'UA-1234567-1', 'auto');\n/* <script src=\"https://www.google-analytics.com/analytics.js\"></script> */\nexport const id = \"G-ABC123XYZ\";",
+		})
+		p.Config.Stack = "node"
+		res := p.Run(checks.DeprecatedServicesCheck{})
+		if !res.Passed {
+			t.Fatalf("expected pass, got %q (%v)", res.Message, res.Details)
+		}
+	})
+
+	t.Run("free size only counts in app.json", func(t *testing.T) {
+		p := checktest.NewProject(t, map[string]string{
+			"src/plans.js": `export const plans = [{"size": "free", "price": 0}]`,
+		})
+		p.Config.Stack = "node"
+		res := p.Run(checks.DeprecatedServicesCheck{})
+		if !res.Passed {
+			t.Fatalf("expected pass, got %q", res.Message)
+		}
+	})
+}
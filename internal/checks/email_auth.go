@@ -42,8 +42,8 @@ func (c EmailAuthCheck) Run(ctx Context) (CheckResult, error) {
 		}, nil
 	}
 
-	hasSPF, spfRecord, spfErr := checkSPF(domain)
-	hasDMARC, dmarcRecord, dmarcErr := checkDMARC(domain)
+	hasSPF, spfRecord, spfErr := checkSPF(ctx, domain)
+	hasDMARC, dmarcRecord, dmarcErr := checkDMARC(ctx, domain)
 
 	// If DNS lookups failed, report the error instead of claiming records are missing
 	if spfErr != nil || dmarcErr != nil {
@@ -54,16 +54,20 @@ func (c EmailAuthCheck) Run(ctx Context) (CheckResult, error) {
 		if dmarcErr != nil {
 			errParts = append(errParts, fmt.Sprintf("DMARC lookup failed: %v", dmarcErr))
 		}
+		suggestions := []string{
+			"Check your network connection and DNS resolver",
+			"Verify the domain is correct in your production URL",
+		}
+		if ctx.Resolver == nil {
+			suggestions = append(suggestions, "If this network blocks DNS, set dns.resolver: cloudflare in preflight.yml to use DNS-over-HTTPS")
+		}
 		return CheckResult{
-			ID:       c.ID(),
-			Title:    c.Title(),
-			Severity: SeverityWarn,
-			Passed:   false,
-			Message:  fmt.Sprintf("DNS lookup error for %s: %s", domain, strings.Join(errParts, "; ")),
-			Suggestions: []string{
-				"Check your network connection and DNS resolver",
-				"Verify the domain is correct in your production URL",
-			},
+			ID:          c.ID(),
+			Title:       c.Title(),
+			Severity:    SeverityWarn,
+			Passed:      false,
+			Message:     fmt.Sprintf("DNS lookup error for %s: %s", domain, strings.Join(errParts, "; ")),
+			Suggestions: suggestions,
 		}, nil
 	}
 
@@ -120,6 +124,17 @@ func extractDomain(rawURL string) (string, error) {
 
 const fallbackDNSServer = "1.1.1.1:53"
 
+// lookupTXT resolves name with the scan's configured resolver, or the
+// system resolver (with a public fallback) when none is configured.
+func (c Context) lookupTXT(name string) ([]string, error) {
+	if c.Resolver == nil {
+		return dnsLookupTXT(name)
+	}
+	ctx, cancel := context.WithTimeout(c.reqContext(), 5*time.Second)
+	defer cancel()
+	return c.Resolver.LookupTXT(ctx, name)
+}
+
 func dnsLookupTXT(name string) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -149,8 +164,8 @@ func dnsLookupTXT(name string) ([]string, error) {
 	return fallback.LookupTXT(fbCtx, name)
 }
 
-func checkSPF(domain string) (bool, string, error) {
-	records, err := dnsLookupTXT(domain)
+func checkSPF(ctx Context, domain string) (bool, string, error) {
+	records, err := ctx.lookupTXT(domain)
 	if err != nil {
 		var dnsErr *net.DNSError
 		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
@@ -167,8 +182,8 @@ func checkSPF(domain string) (bool, string, error) {
 	return false, "", nil
 }
 
-func checkDMARC(domain string) (bool, string, error) {
-	records, err := dnsLookupTXT("_dmarc." + domain)
+func checkDMARC(ctx Context, domain string) (bool, string, error) {
+	records, err := ctx.lookupTXT("_dmarc." + domain)
 	if err != nil {
 		var dnsErr *net.DNSError
 		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
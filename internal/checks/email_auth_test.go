@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+// fakeResolver serves TXT and IP records from maps; other names are
+// NXDOMAIN, and names in fail return a temporary error.
+type fakeResolver struct {
+	txt  map[string][]string
+	ips  map[string][]net.IP
+	fail map[string]bool
+}
+
+func (r fakeResolver) LookupIP(_ context.Context, network, host string) ([]net.IP, error) {
+	if r.fail[host] {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true, IsTemporary: true}
+	}
+	var ips []net.IP
+	for _, ip := range r.ips[host] {
+		if (network == "ip6") == (ip.To4() == nil) || network == "ip" {
+			ips = append(ips, ip)
+		}
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return ips, nil
+}
+
+func (r fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if r.fail[name] {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true, IsTemporary: true}
+	}
+	records, ok := r.txt[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestEmailAuthUsesConfiguredResolver(t *testing.T) {
+	cfg := &config.PreflightConfig{URLs: config.URLConfig{Production: "https://www.example.com"}}
+	run := func(r fakeResolver) CheckResult {
+		t.Helper()
+		res, err := EmailAuthCheck{}.Run(Context{Config: cfg, Resolver: r})
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return res
+	}
+
+	res := run(fakeResolver{txt: map[string][]string{
+		"www.example.com":        {"v=spf1 -all"},
+		"_dmarc.www.example.com": {"v=DMARC1; p=reject"},
+	}})
+	if !res.Passed {
+		t.Errorf("SPF and DMARC present: Passed = false, Message %q", res.Message)
+	}
+
+	res = run(fakeResolver{txt: map[string][]string{"www.example.com": {"v=spf1 -all"}}})
+	if res.Passed || res.Message != "Missing: DMARC" {
+		t.Errorf("DMARC NXDOMAIN: Passed = %v, Message %q; want Missing: DMARC", res.Passed, res.Message)
+	}
+
+	res = run(fakeResolver{fail: map[string]bool{"www.example.com": true}})
+	if res.Passed || !strings.Contains(res.Message, "DNS lookup error") {
+		t.Errorf("resolver failure: Passed = %v, Message %q; want a lookup error", res.Passed, res.Message)
+	}
+}
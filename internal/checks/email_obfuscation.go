@@ -0,0 +1,206 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+var (
+	reEmailAddress = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`)
+	// Retina asset names (logo@2x.png) and placeholder domains look like
+	// addresses but aren't exposed to anyone.
+	reEmailFalsePositive = regexp.MustCompile(`(?i)(\.(png|jpe?g|gif|svg|webp|avif|ico|css|js|mjs|ts)$|@(example\.(com|org|net)|domain\.com|email\.com|sentry\.io)$)`)
+)
+
+// emailTemplateExtensions are the markup files whose text reaches
+// visitors. Plain source code is left out: addresses there are usually
+// config, not page content.
+var emailTemplateExtensions = map[string]bool{
+	".html": true, ".htm": true, ".php": true, ".twig": true, ".erb": true,
+	".haml": true, ".slim": true, ".ejs": true, ".pug": true, ".hbs": true,
+	".handlebars": true, ".mustache": true, ".njk": true, ".liquid": true,
+	".vue": true, ".svelte": true, ".astro": true, ".jsx": true, ".tsx": true,
+	".tmpl": true, ".gohtml": true, ".md": true, ".mdx": true,
+}
+
+// EmailObfuscationCheck flags email addresses published in plain text
+// (mailto: links or bare addresses) where scrapers harvest them for spam.
+type EmailObfuscationCheck struct{}
+
+func (c EmailObfuscationCheck) ID() string {
+	return "email_obfuscation"
+}
+
+func (c EmailObfuscationCheck) Title() string {
+	return "Email address exposure"
+}
+
+func (c EmailObfuscationCheck) Run(ctx Context) (CheckResult, error) {
+	var findings []string
+	addresses := make(map[string]bool)
+	mailtos := make(map[string]bool)
+
+	record := func(where, line string) {
+		for _, addr := range reEmailAddress.FindAllString(line, -1) {
+			if reEmailFalsePositive.MatchString(addr) {
+				continue
+			}
+			addr = strings.ToLower(addr)
+			kind := "plain text"
+			if strings.Contains(strings.ToLower(line), "mailto:"+addr) {
+				kind = "mailto: link"
+				mailtos[addr] = true
+			}
+			addresses[addr] = true
+			findings = append(findings, fmt.Sprintf("%s - %s (%s)", where, addr, kind))
+		}
+	}
+
+	// The live homepage is what harvesters actually crawl; services like
+	// Cloudflare Email Obfuscation rewrite addresses there even when the
+	// templates contain them.
+	page := ctx.PageHTMLProduction
+	if page == "" {
+		page = ctx.PageHTML
+	}
+	livePassed := false
+	if page != "" {
+		before := len(findings)
+		for _, line := range strings.Split(page, "\n") {
+			record("homepage (live)", line)
+		}
+		livePassed = len(findings) == before
+	}
+
+	for _, f := range scanTemplatesForEmails(ctx.RootDir, ctx.Config.Ignore) {
+		record(f.where, f.line)
+	}
+
+	if len(findings) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No plain-text email addresses found",
+		}, nil
+	}
+
+	message := fmt.Sprintf("%d email address(es) exposed to harvesters", len(addresses))
+	if len(mailtos) > 0 {
+		message += fmt.Sprintf(", %d via mailto: links", len(mailtos))
+	}
+	if livePassed {
+		message += " in templates (not visible on the live homepage)"
+	}
+
+	// Limit findings shown
+	maxFindings := 5
+	var details []string
+	for i, finding := range findings {
+		if i >= maxFindings {
+			details = append(details, fmt.Sprintf("... and %d more", len(findings)-maxFindings))
+			break
+		}
+		details = append(details, finding)
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityWarn,
+		Passed:   false,
+		Message:  message,
+		Suggestions: []string{
+			"Replace public addresses with a contact form",
+			"Or obfuscate them (HTML entities, assembling the address in JavaScript, or Cloudflare Email Address Obfuscation)",
+			"Publish a role alias (hello@, support@) with spam filtering rather than a personal inbox",
+		},
+		Details: details,
+	}, nil
+}
+
+type emailFinding struct {
+	where string
+	line  string
+}
+
+// scanTemplatesForEmails returns every template line that contains an
+// email address, located as "path:line".
+func scanTemplatesForEmails(rootDir string, ignore []string) []emailFinding {
+	var findings []emailFinding
+
+	skipDirs := map[string]bool{
+		"node_modules": true,
+		"vendor":       true,
+		".git":         true,
+		"dist":         true,
+		"build":        true,
+		".next":        true,
+		".nuxt":        true,
+		"coverage":     true,
+		".cache":       true,
+		"tmp":          true,
+		"storage":      true,
+		".turbo":       true,
+		".vercel":      true,
+		".netlify":     true,
+		"_site":        true,
+		"out":          true,
+	}
+
+	_ = walkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !emailTemplateExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		rel := relPath(rootDir, path)
+		for _, g := range ignore {
+			if ok, _ := doublestar.Match(filepath.ToSlash(g), filepath.ToSlash(rel)); ok {
+				return nil
+			}
+		}
+		// README/CHANGELOG-style docs aren't served to visitors.
+		upper := strings.ToUpper(d.Name())
+		if strings.HasPrefix(upper, "README") || strings.HasPrefix(upper, "CHANGELOG") ||
+			strings.HasPrefix(upper, "CONTRIBUTING") || strings.HasPrefix(upper, "CODE_OF_CONDUCT") ||
+			strings.HasPrefix(upper, "SECURITY") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || info.Size() > 500*1024 {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		for lineNum, line := range strings.Split(string(content), "\n") {
+			if reEmailAddress.MatchString(line) {
+				findings = append(findings, emailFinding{where: fmt.Sprintf("%s:%d", rel, lineNum+1), line: line})
+			}
+		}
+		return nil
+	})
+
+	return findings
+}
@@ -0,0 +1,49 @@
+package checks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+)
+
+func TestEmailObfuscationCheck(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		page  string
+		want  bool
+	}{
+		{"no addresses", map[string]string{"index.html": `<a href="/contact">Contact</a>`}, "", true},
+		{"retina asset and placeholder", map[string]string{
+			"index.html": `<img src="logo@2x.png"><input placeholder="you@example.com">`,
+		}, "", true},
+		{"address in source code only", map[string]string{"server.go": `const from = "ops@acme.io"`}, "", true},
+		{"README is not served", map[string]string{"README.md": "Contact ops@acme.io"}, "", true},
+		{"mailto in template", map[string]string{"index.html": `<a href="mailto:hello@acme.io">Email us</a>`}, "", false},
+		{"plain text in markdown", map[string]string{"content/about.md": "Write to jane@acme.io"}, "", false},
+		{"live page only", map[string]string{"index.html": "<html></html>"}, `<p>sales@acme.io</p>`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := checktest.NewProject(t, tc.files)
+			p.Config.Stack = "static"
+			ctx := p.Context()
+			ctx.PageHTML = tc.page
+			res := checktest.Run(t, checks.EmailObfuscationCheck{}, ctx)
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q, %v)", res.Passed, tc.want, res.Message, res.Details)
+			}
+		})
+	}
+}
+
+func TestEmailObfuscationCheck_CountsMailto(t *testing.T) {
+	res := checktest.NewProject(t, map[string]string{
+		"index.html": "<a href=\"mailto:Hello@acme.io\">Hello@acme.io</a>\n<p>jobs@acme.io</p>",
+	}).Run(checks.EmailObfuscationCheck{})
+	if !strings.HasPrefix(res.Message, "2 email address(es)") || !strings.Contains(res.Message, "1 via mailto:") {
+		t.Errorf("Message = %q, want 2 addresses with a mailto: count", res.Message)
+	}
+}
@@ -481,7 +481,7 @@ func hasEnvVarReference(rootDir string, prefixes ...string) (string, bool) {
 	// usual config file types.
 	found := ""
 	configDir := filepath.Join(rootDir, "config")
-	_ = filepath.Walk(configDir, func(path string, fi os.FileInfo, err error) error {
+	_ = walk(configDir, func(path string, fi os.FileInfo, err error) error {
 		if err != nil || found != "" {
 			return nil
 		}
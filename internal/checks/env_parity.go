@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -51,12 +52,18 @@ func (c EnvParityCheck) Run(ctx Context) (CheckResult, error) {
 	if envErr != nil {
 		// .env.example exists but .env doesn't - this is expected for repos
 		// Just note that .env.example documents the required vars
+		message := fmt.Sprintf("%s documents %d required variables", cfg.ExampleFile, len(exampleKeys))
+		// With a secrets manager, variables are injected at runtime
+		// (doppler run, op run, ...) rather than read from a local .env.
+		if managers := detectSecretsManagers(ctx.RootDir, ctx.Config.Stack); len(managers) > 0 {
+			message = fmt.Sprintf("%s documents %d variables, supplied by %s", cfg.ExampleFile, len(exampleKeys), strings.Join(managers, ", "))
+		}
 		return CheckResult{
 			ID:       c.ID(),
 			Title:    c.Title(),
 			Severity: SeverityInfo,
 			Passed:   true,
-			Message:  cfg.ExampleFile + " documents " + fmt.Sprintf("%d", len(exampleKeys)) + " required variables",
+			Message:  message,
 		}, nil
 	}
 
@@ -76,6 +83,9 @@ func (c EnvParityCheck) Run(ctx Context) (CheckResult, error) {
 		}
 	}
 
+	sort.Strings(missingInExample)
+	sort.Strings(missingInEnv)
+
 	if len(missingInExample) == 0 && len(missingInEnv) == 0 {
 		return CheckResult{
 			ID:       c.ID(),
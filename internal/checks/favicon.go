@@ -98,7 +98,7 @@ func (c FaviconCheck) Run(ctx Context) (CheckResult, error) {
 			if _, err := os.Stat(dirPath); err != nil {
 				continue
 			}
-			_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+			_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					if info != nil && info.IsDir() {
 						return filepath.SkipDir
@@ -250,7 +250,7 @@ func (c FaviconCheck) Run(ctx Context) (CheckResult, error) {
 			if _, err := os.Stat(dirPath); err != nil {
 				continue
 			}
-			_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+			_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					if info != nil && info.IsDir() {
 						return filepath.SkipDir
@@ -347,7 +347,7 @@ func (c FaviconCheck) Run(ctx Context) (CheckResult, error) {
 			if _, err := os.Stat(dirPath); err != nil {
 				continue
 			}
-			_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+			_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					if info != nil && info.IsDir() {
 						return filepath.SkipDir
@@ -0,0 +1,101 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+// TestGolden pins the full result (message, suggestions and details) of
+// checks against small fixture projects. Regenerate with -update after an
+// intended change to a check's output.
+func TestGolden(t *testing.T) {
+	cases := []struct {
+		name   string
+		check  checks.Check
+		files  map[string]string
+		config func(*config.PreflightConfig)
+	}{
+		{
+			name:  "seo_meta_missing",
+			check: checks.SEOMetadataCheck{},
+			files: map[string]string{"index.html": `<html><head><title>Home</title></head></html>`},
+			config: func(c *config.PreflightConfig) {
+				c.Checks.SEOMeta = &config.SEOMetaConfig{Enabled: true, MainLayout: "index.html"}
+			},
+		},
+		{
+			name:  "seo_meta_present",
+			check: checks.SEOMetadataCheck{},
+			files: map[string]string{"index.html": `<html><head><title>Home</title>
+<meta name="description" content="d"><meta property="og:title" content="t"><meta property="og:description" content="d">
+</head></html>`},
+			config: func(c *config.PreflightConfig) {
+				c.Checks.SEOMeta = &config.SEOMetaConfig{Enabled: true, MainLayout: "index.html"}
+			},
+		},
+		{
+			name:  "og_twitter_missing",
+			check: checks.OGTwitterCheck{},
+			files: map[string]string{"index.html": `<html><head><meta property="og:type" content="website"></head></html>`},
+			config: func(c *config.PreflightConfig) {
+				c.Checks.SEOMeta = &config.SEOMetaConfig{Enabled: true, MainLayout: "index.html"}
+			},
+		},
+		{
+			name:  "env_parity_drift",
+			check: checks.EnvParityCheck{},
+			files: map[string]string{
+				".env":         "DATABASE_URL=postgres://localhost\nDEBUG_TOOLBAR=1\nSTRIPE_KEY=sk\n",
+				".env.example": "DATABASE_URL=\nSTRIPE_KEY=\nSENTRY_DSN=\nRESEND_API_KEY=\n",
+			},
+			config: func(c *config.PreflightConfig) {
+				c.Checks.EnvParity = &config.EnvParityConfig{Enabled: true, EnvFile: ".env", ExampleFile: ".env.example"}
+			},
+		},
+		{
+			name:  "debug_statements",
+			check: checks.DebugStatementsCheck{},
+			files: map[string]string{
+				"src/app.js":   "console.log('user', user);\nexport default app;\n",
+				"src/util.js":  "export const add = (a, b) => a + b;\n",
+				"package.json": `{"name":"app"}`,
+			},
+			config: func(c *config.PreflightConfig) { c.Stack = "node" },
+		},
+		{
+			name:  "legal_placeholders",
+			check: checks.LegalPlaceholdersCheck{},
+			files: map[string]string{
+				"privacy.html": "<p>[Company Name] collects data. Contact privacy@example.com.</p>",
+				"terms.md":     "Governed by the laws of the State of ________.",
+			},
+			config: func(c *config.PreflightConfig) { c.Stack = "static" },
+		},
+		{
+			name:  "dead_code",
+			check: checks.DeadCodeCheck{},
+			files: map[string]string{
+				"pages/pricing.tsx":   "export default () => <h1>Coming soon</h1>",
+				"src/header-old.php":  "<?php",
+				"templates/page.html": "<main></main>",
+			},
+		},
+		{
+			name:  "secrets_manager_dev_only",
+			check: checks.SecretsManagerCheck{},
+			files: map[string]string{"doppler.yaml": "setup:\n  project: web\n  config: dev\n"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := checktest.NewProject(t, tc.files)
+			if tc.config != nil {
+				tc.config(p.Config)
+			}
+			p.AssertGolden(tc.name, p.Run(tc.check))
+		})
+	}
+}
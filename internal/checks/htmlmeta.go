@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"regexp"
 	"strings"
 
 	"golang.org/x/net/html"
@@ -18,11 +19,31 @@ type renderedDoc struct {
 	title        string              // trimmed text of the first non-empty <title>
 	htmlLang     string              // lang attribute on <html>
 	hasJSONLD    bool                // <script type="application/ld+json"> present
+	scriptSrcs   []string            // src of every <script src=...>
+	inlineJS     []string            // bodies of inline <script> elements
+	pixelSrcs    []string            // src of <img>/<iframe>, where noscript tracking pixels live
+	links        []renderedLink      // <a href> anchors in document order
+	text         string              // visible text, a space where each block-level tag was
 }
 
-// parseRenderedHTML tokenizes doc and collects the head-level signals the
-// checks care about. The tokenizer is tolerant of broken markup and never
-// fails; on garbage input the result is simply empty.
+// inlineTags don't break a word or phrase, so renderedDoc.text doesn't put
+// a space where they start or end ("<b>March 5</b>, 2024").
+var inlineTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdi": true, "cite": true, "code": true,
+	"data": true, "em": true, "i": true, "mark": true, "q": true, "s": true,
+	"small": true, "span": true, "strong": true, "sub": true, "sup": true,
+	"time": true, "u": true,
+}
+
+// renderedLink is an <a> element's href and its visible text.
+type renderedLink struct {
+	href string
+	text string
+}
+
+// parseRenderedHTML tokenizes doc and collects the signals the checks care
+// about. The tokenizer is tolerant of broken markup and never fails; on
+// garbage input the result is simply empty.
 func parseRenderedHTML(doc string) renderedDoc {
 	d := renderedDoc{
 		metaName:     map[string]string{},
@@ -32,23 +53,58 @@ func parseRenderedHTML(doc string) renderedDoc {
 
 	z := html.NewTokenizer(strings.NewReader(doc))
 	inTitle := false
+	// The tokenizer returns <script>/<style>/<noscript> bodies as a single
+	// raw text token, so remembering the last start tag is enough to route
+	// it.
+	rawTag := ""
+	openLink := -1 // index into d.links of the <a> being read
+	var text strings.Builder
 	for {
 		tt := z.Next()
 		switch tt {
 		case html.ErrorToken:
+			d.text = text.String()
 			return d // io.EOF or unrecoverable garbage; keep what we have
 		case html.TextToken:
+			raw := string(z.Text())
+			switch rawTag {
+			case "script":
+				if strings.TrimSpace(raw) != "" {
+					d.inlineJS = append(d.inlineJS, raw)
+				}
+				continue
+			case "style":
+				continue
+			case "noscript":
+				// Tracking pixels and GTM iframes live here.
+				inner := parseRenderedHTML(raw)
+				d.scriptSrcs = append(d.scriptSrcs, inner.scriptSrcs...)
+				d.pixelSrcs = append(d.pixelSrcs, inner.pixelSrcs...)
+				d.links = append(d.links, inner.links...)
+				continue
+			}
 			if inTitle && d.title == "" {
-				d.title = strings.TrimSpace(string(z.Text()))
+				d.title = strings.TrimSpace(raw)
+			}
+			// A non-breaking space (&nbsp;) isn't \s to the regexes that
+			// read this text.
+			raw = strings.ReplaceAll(raw, "\u00a0", " ")
+			text.WriteString(raw)
+			if openLink >= 0 {
+				d.links[openLink].text += raw
 			}
 		case html.StartTagToken, html.SelfClosingTagToken:
 			name, hasAttr := z.TagName()
+			if !inlineTags[string(name)] {
+				text.WriteByte(' ')
+			}
 			attrs := map[string]string{}
 			for hasAttr {
 				var k, v []byte
 				k, v, hasAttr = z.TagAttr()
 				attrs[strings.ToLower(string(k))] = string(v)
 			}
+			rawTag = ""
 			switch string(name) {
 			case "meta":
 				if n := strings.ToLower(strings.TrimSpace(attrs["name"])); n != "" {
@@ -79,10 +135,42 @@ func parseRenderedHTML(doc string) renderedDoc {
 				if strings.Contains(strings.ToLower(attrs["type"]), "application/ld+json") {
 					d.hasJSONLD = true
 				}
+				if src := strings.TrimSpace(attrs["src"]); src != "" {
+					d.scriptSrcs = append(d.scriptSrcs, src)
+				}
+				if tt == html.StartTagToken {
+					rawTag = "script"
+				}
+			case "style", "noscript":
+				if tt == html.StartTagToken {
+					rawTag = string(name)
+				}
+			case "img", "iframe":
+				if src := strings.TrimSpace(attrs["src"]); src != "" {
+					d.pixelSrcs = append(d.pixelSrcs, src)
+				}
+			case "a":
+				if href, ok := attrs["href"]; ok {
+					d.links = append(d.links, renderedLink{href: strings.TrimSpace(href)})
+					if tt == html.StartTagToken {
+						openLink = len(d.links) - 1
+					}
+				}
 			}
 		case html.EndTagToken:
-			if name, _ := z.TagName(); string(name) == "title" {
+			rawTag = ""
+			name, _ := z.TagName()
+			if !inlineTags[string(name)] {
+				text.WriteByte(' ')
+			}
+			switch string(name) {
+			case "title":
 				inTitle = false
+			case "a":
+				if openLink >= 0 {
+					d.links[openLink].text = strings.Join(strings.Fields(d.links[openLink].text), " ")
+					openLink = -1
+				}
 			}
 		}
 	}
@@ -104,3 +192,46 @@ func (d renderedDoc) hasMeta(name string) bool {
 func (d renderedDoc) hasLinkRel(rel string) bool {
 	return len(d.linkRels[strings.ToLower(rel)]) > 0
 }
+
+// metaContent returns the content of the meta tag for name, checking
+// property= before name= (see hasMeta).
+func (d renderedDoc) metaContent(name string) string {
+	key := strings.ToLower(name)
+	if v, ok := d.metaProperty[key]; ok {
+		return strings.TrimSpace(v)
+	}
+	return strings.TrimSpace(d.metaName[key])
+}
+
+// loadsScript reports whether p matches a script the page loads: an
+// external script's URL, an inline script's code, or a tracking pixel's
+// src. Matching only those, rather than the whole page, keeps visible
+// text that merely mentions a vendor from counting.
+func (d renderedDoc) loadsScript(p *regexp.Regexp) bool {
+	for _, group := range [][]string{d.scriptSrcs, d.inlineJS, d.pixelSrcs} {
+		for _, s := range group {
+			if p.MatchString(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasLink reports whether any anchor's href or visible text matches p.
+func (d renderedDoc) hasLink(p *regexp.Regexp) bool {
+	for _, l := range d.links {
+		if p.MatchString(l.href) || p.MatchString(l.text) {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlText returns the visible text of an HTML (or HTML-ish template)
+// document, with block-level tags replaced by a space and script and
+// style bodies removed. Line breaks are kept, so plain text and Markdown pass
+// through unchanged.
+func htmlText(doc string) string {
+	return parseRenderedHTML(doc).text
+}
@@ -1,6 +1,9 @@
 package checks
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestParseRenderedHTML(t *testing.T) {
 	doc := parseRenderedHTML(`<!doctype html>
@@ -68,3 +71,69 @@ func TestParseRenderedHTMLGarbage(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRenderedHTMLBody(t *testing.T) {
+	doc := parseRenderedHTML(`<html><head>
+<script src="https://cdn.example.test/app.js"></script>
+<script>window.dataLayer=[];gtag('config','G-ABC123');</script>
+<style>.x{content:"Last updated: 2001-01-01"}</style>
+</head><body>
+<p>Last&nbsp;updated: <strong>March 5</strong>, 2024</p>
+<a href="/privacy">Privacy <em>policy</em></a>
+<a href='mailto:hi@x.test'>Email</a>
+<noscript><iframe src="https://www.googletagmanager.com/ns.html?id=GTM-AB12"></iframe></noscript>
+</body></html>`)
+
+	if len(doc.scriptSrcs) != 1 || doc.scriptSrcs[0] != "https://cdn.example.test/app.js" {
+		t.Errorf("scriptSrcs = %v", doc.scriptSrcs)
+	}
+	if !doc.loadsScript(reGA4MeasurementID) || !doc.loadsScript(reGTMContainerID) {
+		t.Error("inline gtag config and GTM noscript iframe should count as loaded scripts")
+	}
+	if len(doc.links) != 2 || doc.links[0].href != "/privacy" || doc.links[0].text != "Privacy policy" {
+		t.Errorf("links = %+v", doc.links)
+	}
+	if strings.Contains(doc.text, "2001") || strings.Contains(doc.text, "gtag") {
+		t.Errorf("text should exclude script and style bodies: %q", doc.text)
+	}
+	if got, ok := parseLegalUpdatedDate(doc.text); !ok || got.Format("2006-01-02") != "2024-03-05" {
+		t.Errorf("date from text = %v (%v), want 2024-03-05", got, ok)
+	}
+}
+
+func TestMetaContent(t *testing.T) {
+	doc := parseRenderedHTML(`<meta content=" /og.png " property="og:image"><meta name="twitter:image" content="/tw.png">`)
+	if got := doc.metaContent("og:image"); got != "/og.png" {
+		t.Errorf("og:image = %q", got)
+	}
+	if got := doc.metaContent("TWITTER:IMAGE"); got != "/tw.png" {
+		t.Errorf("twitter:image = %q", got)
+	}
+	if got := doc.metaContent("og:url"); got != "" {
+		t.Errorf("og:url = %q, want empty", got)
+	}
+}
+
+// FuzzParseRenderedHTML feeds arbitrary bytes to the parser, which reads
+// untrusted live pages and must never panic or hang.
+func FuzzParseRenderedHTML(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"<html lang=en><title>x</title><meta property=og:image content=/a.png>",
+		"<script>var s = '</scr' + 'ipt>';</script><a href=/x>y",
+		"<a href=\"/impressum\"><a href=\"/b\">nested</a></a>",
+		"<<<<meta name=><link rel>",
+		"<style><a href=/x>not a link</a></style>",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		d := parseRenderedHTML(in)
+		for _, l := range d.links {
+			if strings.TrimSpace(l.href) != l.href {
+				t.Errorf("link href not trimmed: %q", l.href)
+			}
+		}
+		_ = htmlText(in)
+	})
+}
@@ -0,0 +1,290 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/netutil"
+)
+
+// IPv6Check looks up the production host's AAAA records and requests the
+// site over IPv6, so IPv6-only visitors (common on mobile networks) aren't
+// locked out by a missing record or a listener that only binds IPv4.
+type IPv6Check struct{}
+
+func (c IPv6Check) ID() string {
+	return "ipv6"
+}
+
+func (c IPv6Check) Title() string {
+	return "IPv6 reachability"
+}
+
+func (c IPv6Check) Run(ctx Context) (CheckResult, error) {
+	if ctx.Config.URLs.Production == "" {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No production URL configured, skipping",
+		}, nil
+	}
+	if IsLocalURL(ctx.Config.URLs.Production) {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Skipped for local URL",
+		}, nil
+	}
+
+	rawURL := ctx.Config.URLs.Production
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  "Invalid production URL",
+		}, nil
+	}
+	host := parsed.Hostname()
+	if net.ParseIP(host) != nil {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Production URL is an IP address, skipping",
+		}, nil
+	}
+
+	ips, err := ctx.lookupIP("ip6", host)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityWarn,
+				Passed:   false,
+				Message:  fmt.Sprintf("No AAAA record for %s; IPv6-only visitors can't reach the site", host),
+				Suggestions: []string{
+					"Enable IPv6 on your host or CDN and publish an AAAA record",
+					"Most CDNs (Cloudflare, Fastly, CloudFront, Vercel, Netlify) serve IPv6 with a setting or by default",
+				},
+			}, nil
+		}
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  fmt.Sprintf("AAAA lookup failed for %s: %v", host, err),
+			Suggestions: []string{
+				"Check your network connection and DNS resolver",
+			},
+		}, nil
+	}
+
+	for _, ip := range ips {
+		if netutil.IsPrivateIP(ip) {
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityWarn,
+				Passed:   false,
+				Message:  fmt.Sprintf("AAAA record for %s points to a non-public address (%s)", host, ip),
+				Suggestions: []string{
+					"Publish the site's public IPv6 address, or remove the AAAA record",
+				},
+			}, nil
+		}
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		port = "443"
+		if parsed.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	var lastErr error
+	for _, ip := range ips {
+		status, err := probeIPv6(ctx.reqContext(), ctx.UserAgent, parsed, ip, port)
+		if err == nil {
+			if status >= 500 {
+				return CheckResult{
+					ID:       c.ID(),
+					Title:    c.Title(),
+					Severity: SeverityWarn,
+					Passed:   false,
+					Message:  fmt.Sprintf("%s returns HTTP %d over IPv6 (%s)", host, status, ip),
+					Suggestions: []string{
+						"Check that the IPv6 listener routes to the same backend as IPv4",
+					},
+				}, nil
+			}
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityInfo,
+				Passed:   true,
+				Message:  fmt.Sprintf("%s responds over IPv6 (%s)", host, ip),
+			}, nil
+		}
+		if noIPv6Route(err) {
+			// The runner itself has no IPv6 connectivity (the usual case
+			// on CI), so a failed connection says nothing about the site.
+			return c.verifyRemotely(ctx, host, ip, port), nil
+		}
+		lastErr = err
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityWarn,
+		Passed:   false,
+		Message:  fmt.Sprintf("%s has an AAAA record but doesn't respond over IPv6: %v", host, lastErr),
+		Suggestions: []string{
+			"Check that the server or load balancer listens on IPv6 and the firewall allows it",
+			"Remove the AAAA record if the site isn't meant to be served over IPv6",
+		},
+	}, nil
+}
+
+// verifyRemotely asks check-host.net nodes to open a TCP connection to ip,
+// for runners without an IPv6 route. A node without IPv6 fails too, so
+// only a connection proves anything; otherwise reachability is reported
+// as unverified.
+func (c IPv6Check) verifyRemotely(ctx Context, host string, ip net.IP, port string) CheckResult {
+	unverified := CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityInfo,
+		Passed:   false,
+		Message:  fmt.Sprintf("AAAA record present for %s (%s), but IPv6 reachability is unverified: this machine has no IPv6 route and no remote check connected", host, ip),
+		Suggestions: []string{
+			"Run the scan from a machine with IPv6 connectivity to verify",
+		},
+	}
+
+	client := remoteClient(ctx.UserAgent)
+	nodes, err := checkHostNodes(ctx, client)
+	if err != nil {
+		unverified.Details = []string{fmt.Sprintf("check-host.net: %v", err)}
+		return unverified
+	}
+	answers, err := checkHostRun(ctx, client, "tcp", net.JoinHostPort(ip.String(), port), nodes)
+	if err != nil {
+		unverified.Details = []string{fmt.Sprintf("check-host.net: %v", err)}
+		return unverified
+	}
+	for _, n := range nodes {
+		connected, detail := parseCheckHostTCP(answers[n.name])
+		if connected {
+			return CheckResult{
+				ID:       c.ID(),
+				Title:    c.Title(),
+				Severity: SeverityInfo,
+				Passed:   true,
+				Message:  fmt.Sprintf("%s accepts IPv6 connections (%s, checked from %s)", host, ip, n.region()),
+			}
+		}
+		unverified.Details = append(unverified.Details, fmt.Sprintf("%s: %s", n.region(), detail))
+	}
+	return unverified
+}
+
+// parseCheckHostTCP reads one node's check-tcp result: [{"time": seconds,
+// "address": ip}] on a connection, [{"error": message}] otherwise, or null
+// while pending.
+func parseCheckHostTCP(raw json.RawMessage) (connected bool, detail string) {
+	var rows []struct {
+		Time    float64 `json:"time"`
+		Address string  `json:"address"`
+		Error   string  `json:"error"`
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return false, "no answer in time"
+	}
+	if err := json.Unmarshal(raw, &rows); err != nil || len(rows) == 0 {
+		return false, "unexpected check-host.net result"
+	}
+	if rows[0].Error != "" {
+		return false, rows[0].Error
+	}
+	return rows[0].Address != "", fmt.Sprintf("connected in %.2fs", rows[0].Time)
+}
+
+// lookupIP resolves host with the scan's configured resolver, or the
+// system resolver when none is configured.
+func (c Context) lookupIP(network, host string) ([]net.IP, error) {
+	var r netutil.Resolver = net.DefaultResolver
+	if c.Resolver != nil {
+		r = c.Resolver
+	}
+	ctx, cancel := context.WithTimeout(c.reqContext(), 5*time.Second)
+	defer cancel()
+	return r.LookupIP(ctx, network, host)
+}
+
+// probeIPv6 requests the production URL from ip, keeping the real host
+// for SNI, certificate verification and the Host header, and returns the
+// status code.
+func probeIPv6(ctx context.Context, userAgent string, u *url.URL, ip net.IP, port string) (int, error) {
+	addr := net.JoinHostPort(ip.String(), port)
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "tcp6", addr)
+			},
+			TLSClientConfig:   &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12},
+			DisableKeepAlives: true,
+		},
+		// The redirect target may live elsewhere; reaching this host is
+		// what's being tested.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	client = netutil.Polite(client, userAgent)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// noIPv6Route reports whether err means the local machine can't send
+// IPv6 traffic at all, as opposed to the remote host not answering.
+func noIPv6Route(err error) bool {
+	return errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EADDRNOTAVAIL) ||
+		errors.Is(err, syscall.EAFNOSUPPORT)
+}
@@ -0,0 +1,109 @@
+package checks
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestIPv6CheckDNS(t *testing.T) {
+	cfg := &config.PreflightConfig{URLs: config.URLConfig{Production: "https://example.com"}}
+	cases := []struct {
+		name     string
+		resolver fakeResolver
+		want     string
+	}{
+		{"no AAAA", fakeResolver{ips: map[string][]net.IP{"example.com": {net.ParseIP("93.184.216.34")}}}, "No AAAA record"},
+		{"private AAAA", fakeResolver{ips: map[string][]net.IP{"example.com": {net.ParseIP("fd00::1")}}}, "non-public address"},
+		{"lookup error", fakeResolver{fail: map[string]bool{"example.com": true}}, "AAAA lookup failed"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := IPv6Check{}.Run(Context{Config: cfg, Resolver: tc.resolver})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if res.Passed || !strings.Contains(res.Message, tc.want) {
+				t.Errorf("Passed = %v, Message %q; want a warning containing %q", res.Passed, res.Message, tc.want)
+			}
+		})
+	}
+}
+
+func TestProbeIPv6(t *testing.T) {
+	ln, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("no IPv6 loopback: %v", err)
+	}
+	var gotHost, gotUA string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotUA = r.Host, r.UserAgent()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	srv.Listener = ln
+	srv.Start()
+	defer srv.Close()
+
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	u, _ := url.Parse("http://www.example.com:" + port + "/")
+	status, err := probeIPv6(context.Background(), "preflight/test", u, net.ParseIP("::1"), port)
+	if err != nil {
+		t.Fatalf("probeIPv6: %v", err)
+	}
+	if status != http.StatusServiceUnavailable || gotHost != "www.example.com:"+port {
+		t.Errorf("status %d, Host %q; want 503 from the site's own Host header", status, gotHost)
+	}
+	if gotUA != "preflight/test" {
+		t.Errorf("User-Agent = %q, want preflight/test", gotUA)
+	}
+}
+
+func TestIPv6CheckVerifyRemotely(t *testing.T) {
+	var result string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes/hosts":
+			w.Write([]byte(`{"nodes":{
+				"de1.node.check-host.net":{"location":["de","Germany","Frankfurt"]},
+				"us1.node.check-host.net":{"location":["us","USA","Los Angeles"]}
+			}}`))
+		case "/check-tcp":
+			if host := r.URL.Query().Get("host"); host != "[2001:db8::1]:443" {
+				t.Errorf("check-tcp host = %q", host)
+			}
+			w.Write([]byte(`{"ok":1,"request_id":"v6"}`))
+		case "/check-result/v6":
+			w.Write([]byte(result))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	useRemoteClient(t, srv)
+	oldAPI, oldPoll := checkHostAPI, checkHostPoll
+	checkHostAPI, checkHostPoll = srv.URL, time.Millisecond
+	defer func() { checkHostAPI, checkHostPoll = oldAPI, oldPoll }()
+
+	ip := net.ParseIP("2001:db8::1")
+
+	// A node without IPv6 can't connect either; one that connects is proof.
+	result = `{"de1.node.check-host.net":[{"error":"Network is unreachable"}],"us1.node.check-host.net":[{"time":0.08,"address":"2001:db8::1"}]}`
+	res := IPv6Check{}.verifyRemotely(Context{}, "example.com", ip, "443")
+	if !res.Passed || !strings.Contains(res.Message, "North America") {
+		t.Errorf("Passed = %v, Message %q; want a pass from the connecting node", res.Passed, res.Message)
+	}
+
+	// No connection anywhere leaves it unverified, not passed.
+	result = `{"de1.node.check-host.net":[{"error":"Connection timed out"}],"us1.node.check-host.net":[{"error":"Network is unreachable"}]}`
+	res = IPv6Check{}.verifyRemotely(Context{}, "example.com", ip, "443")
+	if res.Passed || res.Severity != SeverityInfo || !strings.Contains(res.Message, "unverified") || len(res.Details) != 2 {
+		t.Errorf("Passed = %v, Severity %s, Message %q, Details %q; want unverified info", res.Passed, res.Severity, res.Message, res.Details)
+	}
+}
@@ -1,7 +1,6 @@
 package checks
 
 import (
-	"context"
 	"net/http"
 	"net/url"
 	"os"
@@ -9,19 +8,92 @@ import (
 	"strings"
 )
 
-// getWithContext is a context-aware GET that, unlike doGet, does not set
-// a User-Agent. Used by legal-page probing where we historically called
-// http.Client.Get directly so as not to identify Preflight to the
-// scanned host.
-func getWithContext(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
-	if ctx == nil {
-		ctx = context.Background()
+// Conventional URL paths for privacy and terms pages, probed over HTTP.
+var (
+	legalPrivacyURLPaths = []string{
+		"/privacy", "/privacy-policy", "/privacypolicy",
+		"/legal/privacy", "/legal/privacy-policy",
+		"/policies/privacy", "/policies/privacy-policy",
+		"/privacy-notice", "/privacy-statement",
+		"/info/privacy", "/about/privacy",
 	}
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
+	legalTermsURLPaths = []string{
+		"/terms", "/terms-of-service", "/termsofservice", "/tos",
+		"/legal/terms", "/legal/terms-of-service", "/legal/tos",
+		"/policies/terms", "/policies/terms-of-service",
+		"/terms-and-conditions", "/terms-conditions",
+		"/info/terms", "/about/terms", "/eula",
+	}
+)
+
+// Conventional privacy/terms page file names (without extension), the
+// extensions they're written in, and the directories they live in.
+var (
+	legalPrivacyNames = []string{
+		"privacy", "privacy-policy", "privacy_policy", "privacypolicy",
+		"legal/privacy", "legal/privacy-policy",
+		"pages/privacy", "pages/privacy-policy",
+		"policies/privacy", "policies/privacy-policy",
+		"legalese/privacy", "legalese/privacy-policy",
+		"info/privacy", "about/privacy",
+		"privacy-notice", "privacy-statement",
+	}
+	legalTermsNames = []string{
+		"terms", "terms-of-service", "terms_of_service", "tos", "termsofservice",
+		"legal/terms", "legal/terms-of-service", "legal/tos",
+		"pages/terms", "pages/terms-of-service",
+		"policies/terms", "policies/terms-of-service",
+		"legalese/terms", "legalese/terms-of-service",
+		"info/terms", "about/terms",
+		"terms-and-conditions", "terms-conditions", "eula",
+	}
+	legalPageExtensions = []string{
+		"", ".html", ".htm", ".php", ".md", ".mdx",
+		".tsx", ".jsx", ".js", ".ts", ".vue", ".svelte",
+		".erb", ".erb.html", ".html.erb",
+		".blade.php", ".twig", ".njk", ".liquid",
+		".astro",
+	}
+	legalPageDirs = []string{
+		"",
+		"app",
+		"src/app",
+		"src/pages",
+		"pages",
+		"views",
+		"resources/views",
+		"templates",
+		"content",
+		"public",
+		"static",
+		"web",
+		"www",
+		"htdocs",
+		"public_html",
 	}
-	return client.Do(req)
+)
+
+// findLegalPageFile returns the project-relative path of the first legal
+// page file matching names in the conventional directories, or "" if none
+// exists. Next.js app router pages (app/privacy/page.tsx) are included.
+func findLegalPageFile(rootDir string, names []string) string {
+	for _, dir := range legalPageDirs {
+		for _, name := range names {
+			for _, ext := range legalPageExtensions {
+				if info, err := os.Stat(filepath.Join(rootDir, dir, name+ext)); err == nil && !info.IsDir() {
+					return filepath.Join(dir, name+ext)
+				}
+				// Also check with /page pattern for Next.js app router
+				if dir == "app" || dir == "src/app" {
+					pagePath := filepath.Join(dir, name, "page"+ext)
+					if _, err := os.Stat(filepath.Join(rootDir, pagePath)); err == nil {
+						return pagePath
+					}
+				}
+			}
+		}
+	}
+	return ""
 }
 
 type LegalPagesCheck struct{}
@@ -60,18 +132,11 @@ func (c LegalPagesCheck) Run(ctx Context) (CheckResult, error) {
 		}
 		client := &clientCopy
 
-		privacyURLs := []string{
-			"/privacy", "/privacy-policy", "/privacypolicy",
-			"/legal/privacy", "/legal/privacy-policy",
-			"/policies/privacy", "/policies/privacy-policy",
-			"/privacy-notice", "/privacy-statement",
-			"/info/privacy", "/about/privacy",
-		}
-		for _, path := range privacyURLs {
+		for _, path := range legalPrivacyURLPaths {
 			if hasPrivacy {
 				break
 			}
-			resp, err := getWithContext(ctx.reqContext(), client, baseURL+path)
+			resp, err := doGet(ctx.reqContext(), client, baseURL+path)
 			if err == nil {
 				resp.Body.Close()
 				if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -90,18 +155,11 @@ func (c LegalPagesCheck) Run(ctx Context) (CheckResult, error) {
 			}
 		}
 
-		termsURLs := []string{
-			"/terms", "/terms-of-service", "/termsofservice", "/tos",
-			"/legal/terms", "/legal/terms-of-service", "/legal/tos",
-			"/policies/terms", "/policies/terms-of-service",
-			"/terms-and-conditions", "/terms-conditions",
-			"/info/terms", "/about/terms", "/eula",
-		}
-		for _, path := range termsURLs {
+		for _, path := range legalTermsURLPaths {
 			if hasTerms {
 				break
 			}
-			resp, err := getWithContext(ctx.reqContext(), client, baseURL+path)
+			resp, err := doGet(ctx.reqContext(), client, baseURL+path)
 			if err == nil {
 				resp.Body.Close()
 				if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -129,111 +187,16 @@ func (c LegalPagesCheck) Run(ctx Context) (CheckResult, error) {
 		}
 	}
 
-	// Common privacy policy paths/filenames
-	privacyPatterns := []string{
-		"privacy", "privacy-policy", "privacy_policy", "privacypolicy",
-		"legal/privacy", "legal/privacy-policy",
-		"pages/privacy", "pages/privacy-policy",
-		"policies/privacy", "policies/privacy-policy",
-		"legalese/privacy", "legalese/privacy-policy",
-		"info/privacy", "about/privacy",
-		"privacy-notice", "privacy-statement",
-	}
-
-	// Common terms paths/filenames
-	termsPatterns := []string{
-		"terms", "terms-of-service", "terms_of_service", "tos", "termsofservice",
-		"legal/terms", "legal/terms-of-service", "legal/tos",
-		"pages/terms", "pages/terms-of-service",
-		"policies/terms", "policies/terms-of-service",
-		"legalese/terms", "legalese/terms-of-service",
-		"info/terms", "about/terms",
-		"terms-and-conditions", "terms-conditions", "eula",
-	}
-
-	// Extensions to check
-	extensions := []string{
-		"", ".html", ".htm", ".php", ".md", ".mdx",
-		".tsx", ".jsx", ".js", ".ts", ".vue", ".svelte",
-		".erb", ".erb.html", ".html.erb",
-		".blade.php", ".twig", ".njk", ".liquid",
-		".astro",
-	}
-
-	// Directories to search
-	searchDirs := []string{
-		"",
-		"app",
-		"src/app",
-		"src/pages",
-		"pages",
-		"views",
-		"resources/views",
-		"templates",
-		"content",
-		"public",
-		"static",
-		"web",
-		"www",
-		"htdocs",
-		"public_html",
-	}
-
-	// Check for privacy policy
-	for _, dir := range searchDirs {
-		if hasPrivacy {
-			break
-		}
-		for _, pattern := range privacyPatterns {
-			if hasPrivacy {
-				break
-			}
-			for _, ext := range extensions {
-				checkPath := filepath.Join(ctx.RootDir, dir, pattern+ext)
-				if _, err := os.Stat(checkPath); err == nil {
-					hasPrivacy = true
-					privacyPath = filepath.Join(dir, pattern+ext)
-					break
-				}
-				// Also check with /page pattern for Next.js app router
-				if dir == "app" || dir == "src/app" {
-					pagePath := filepath.Join(ctx.RootDir, dir, pattern, "page"+ext)
-					if _, err := os.Stat(pagePath); err == nil {
-						hasPrivacy = true
-						privacyPath = filepath.Join(dir, pattern, "page"+ext)
-						break
-					}
-				}
-			}
+	if !hasPrivacy {
+		if path := findLegalPageFile(ctx.RootDir, legalPrivacyNames); path != "" {
+			hasPrivacy = true
+			privacyPath = path
 		}
 	}
-
-	// Check for terms
-	for _, dir := range searchDirs {
-		if hasTerms {
-			break
-		}
-		for _, pattern := range termsPatterns {
-			if hasTerms {
-				break
-			}
-			for _, ext := range extensions {
-				checkPath := filepath.Join(ctx.RootDir, dir, pattern+ext)
-				if _, err := os.Stat(checkPath); err == nil {
-					hasTerms = true
-					termsPath = filepath.Join(dir, pattern+ext)
-					break
-				}
-				// Also check with /page pattern for Next.js app router
-				if dir == "app" || dir == "src/app" {
-					pagePath := filepath.Join(ctx.RootDir, dir, pattern, "page"+ext)
-					if _, err := os.Stat(pagePath); err == nil {
-						hasTerms = true
-						termsPath = filepath.Join(dir, pattern, "page"+ext)
-						break
-					}
-				}
-			}
+	if !hasTerms {
+		if path := findLegalPageFile(ctx.RootDir, legalTermsNames); path != "" {
+			hasTerms = true
+			termsPath = path
 		}
 	}
 
@@ -249,7 +212,7 @@ func (c LegalPagesCheck) Run(ctx Context) (CheckResult, error) {
 			if _, err := os.Stat(dirPath); err != nil {
 				continue
 			}
-			_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+			_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 				if err != nil || (hasPrivacy && hasTerms) {
 					return nil
 				}
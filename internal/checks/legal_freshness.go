@@ -0,0 +1,259 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/netutil"
+)
+
+// defaultLegalMaxAgeDays is how old a policy's "last updated" date may be
+// before legal_freshness warns, unless checks.legalFreshness.maxAgeDays
+// overrides it.
+const defaultLegalMaxAgeDays = 365
+
+var (
+	// reLegalDateLabel matches the label that precedes a policy's date:
+	// "Last updated:", "Effective date", "Revised on", ...
+	reLegalDateLabel = regexp.MustCompile(`(?i)(last\s+(updated|modified|revised|changed)|effective(\s+date)?|updated|revised)(\s+(on|as\s+of))?\s*[:\-–—]?\s*`)
+	// reLegalDate matches the date right after a label. Numeric
+	// day/month forms (03/04/2024) are ambiguous and deliberately skipped.
+	reLegalDate = regexp.MustCompile(`(?i)^(\d{4}-\d{1,2}-\d{1,2}|[a-z]{3,9}\.?\s+\d{1,2}(st|nd|rd|th)?,?\s+\d{4}|\d{1,2}(st|nd|rd|th)?\s+[a-z]{3,9}\.?,?\s+\d{4}|[a-z]{3,9}\.?,?\s+\d{4})`)
+	reOrdinal   = regexp.MustCompile(`(?i)(\d)(st|nd|rd|th)\b`)
+	// "Sept" is common but isn't a Go month abbreviation.
+	reSept = regexp.MustCompile(`(?i)\bsept\b`)
+)
+
+var legalDateLayouts = []string{
+	"2006-1-2",
+	"January 2 2006", "Jan 2 2006",
+	"2 January 2006", "2 Jan 2006",
+	"January 2006", "Jan 2006",
+}
+
+// legalPage is a privacy or terms page located either in the codebase or
+// on the live site.
+type legalPage struct {
+	name     string // "privacy policy" or "terms of service"
+	location string
+	content  string
+}
+
+// LegalFreshnessCheck parses the privacy policy and terms for a "last
+// updated" date and warns when it's stale, or when trackers were added to
+// the codebase after the policy was last revised.
+type LegalFreshnessCheck struct{}
+
+func (c LegalFreshnessCheck) ID() string {
+	return "legal_freshness"
+}
+
+func (c LegalFreshnessCheck) Title() string {
+	return "Legal pages last updated"
+}
+
+func (c LegalFreshnessCheck) Run(ctx Context) (CheckResult, error) {
+	maxAgeDays := defaultLegalMaxAgeDays
+	if cfg := ctx.Config.Checks.LegalFreshness; cfg != nil && cfg.MaxAgeDays > 0 {
+		maxAgeDays = cfg.MaxAgeDays
+	}
+
+	pages := findLegalPages(ctx)
+	if len(pages) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No privacy policy or terms found, skipping",
+		}, nil
+	}
+
+	now := time.Now()
+	var problems []string
+	var suggestions []string
+	var details []string
+	var undated []string
+
+	for _, page := range pages {
+		updated, ok := parseLegalUpdatedDate(page.content)
+		if !ok {
+			undated = append(undated, page.name)
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s (%s): last updated %s", page.name, page.location, updated.Format("2006-01-02")))
+
+		ageDays := int(now.Sub(updated).Hours() / 24)
+		if ageDays > maxAgeDays {
+			problems = append(problems, fmt.Sprintf("%s last updated %d days ago", page.name, ageDays))
+			suggestions = append(suggestions, fmt.Sprintf("Review the %s and bump its \"last updated\" date (threshold: %d days)", page.name, maxAgeDays))
+		}
+
+		// Only the privacy policy has to disclose trackers.
+		if page.name != "privacy policy" {
+			continue
+		}
+		if added := trackersAddedSince(ctx, updated); len(added) > 0 {
+			problems = append(problems, fmt.Sprintf("%s predates %s", page.name, strings.Join(added, ", ")))
+			suggestions = append(suggestions, fmt.Sprintf("Disclose %s in the privacy policy and update its date", strings.Join(added, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return CheckResult{
+			ID:          c.ID(),
+			Title:       c.Title(),
+			Severity:    SeverityWarn,
+			Passed:      false,
+			Message:     strings.Join(problems, "; "),
+			Suggestions: suggestions,
+			Details:     details,
+		}, nil
+	}
+
+	if len(undated) == len(pages) {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No \"last updated\" date found in " + strings.Join(undated, " or "),
+			Suggestions: []string{
+				"Add a \"Last updated: <date>\" line near the top of each legal page",
+			},
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityInfo,
+		Passed:   true,
+		Message:  fmt.Sprintf("Legal pages updated within the last %d days", maxAgeDays),
+		Details:  details,
+	}, nil
+}
+
+// findLegalPages returns the privacy and terms pages, preferring the live
+// production site (what visitors actually read) and falling back to
+// files in the codebase.
+func findLegalPages(ctx Context) []legalPage {
+	baseURL := ctx.Config.URLs.Production
+	if baseURL == "" {
+		baseURL = ctx.Config.URLs.Staging
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	var pages []legalPage
+	for _, kind := range []struct {
+		name  string
+		paths []string
+		files []string
+	}{
+		{"privacy policy", legalPrivacyURLPaths, legalPrivacyNames},
+		{"terms of service", legalTermsURLPaths, legalTermsNames},
+	} {
+		if baseURL != "" && ctx.Client != nil {
+			if url, body := fetchFirstPage(ctx, baseURL, kind.paths); body != "" {
+				pages = append(pages, legalPage{name: kind.name, location: url, content: body})
+				continue
+			}
+		}
+		if path := findLegalPageFile(ctx.RootDir, kind.files); path != "" {
+			content, err := os.ReadFile(filepath.Join(ctx.RootDir, path))
+			if err == nil {
+				pages = append(pages, legalPage{name: kind.name, location: path, content: string(content)})
+			}
+		}
+	}
+	return pages
+}
+
+// fetchFirstPage returns the URL and body of the first path under baseURL
+// that answers 2xx, or empty strings if none do.
+func fetchFirstPage(ctx Context, baseURL string, paths []string) (string, string) {
+	for _, path := range paths {
+		resp, err := doGet(ctx.reqContext(), ctx.Client, baseURL+path)
+		if err != nil {
+			continue
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			continue
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, netutil.MaxResponseBody))
+		resp.Body.Close()
+		if err == nil && len(body) > 0 {
+			return baseURL + path, string(body)
+		}
+	}
+	return "", ""
+}
+
+// parseLegalUpdatedDate finds the most recent date that follows a "last
+// updated"/"effective" label in content (HTML, Markdown or template
+// source).
+func parseLegalUpdatedDate(content string) (time.Time, bool) {
+	text := htmlText(content)
+
+	var latest time.Time
+	for _, loc := range reLegalDateLabel.FindAllStringIndex(text, -1) {
+		rest := text[loc[1]:]
+		m := reLegalDate.FindString(rest)
+		if m == "" {
+			continue
+		}
+		t, ok := parseLegalDate(m)
+		if ok && t.After(latest) {
+			latest = t
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
+func parseLegalDate(s string) (time.Time, bool) {
+	s = reOrdinal.ReplaceAllString(s, "$1")
+	s = strings.NewReplacer(",", " ", ".", " ").Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	s = reSept.ReplaceAllString(s, "Sep")
+
+	for _, layout := range legalDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// trackersAddedSince returns the trackers present in the codebase today
+// that weren't in it at since, according to git history. It returns nil
+// when the project isn't a git repo or has no commit that old.
+func trackersAddedSince(ctx Context, since time.Time) []string {
+	base, err := runGit(ctx.RootDir, "rev-list", "-1", "--before="+since.Format(time.RFC3339), "HEAD")
+	base = strings.TrimSpace(base)
+	if err != nil || base == "" {
+		return nil
+	}
+
+	var added []string
+	for _, t := range trackerPatterns {
+		if !searchForPatterns(ctx.RootDir, ctx.Config.Stack, []*regexp.Regexp{t.pattern}) {
+			continue
+		}
+		// git grep exits 1 when nothing matches; any other failure means
+		// we can't tell, so don't flag.
+		_, err := runGit(ctx.RootDir, "grep", "-q", "-E", t.pattern.String(), base, "--")
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			added = append(added, t.name)
+		}
+	}
+	return added
+}
@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestParseLegalUpdatedDate(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"Last updated: January 5, 2024", "2024-01-05"},
+		{"<p><strong>Last Updated:</strong> 5th March 2023</p>", "2023-03-05"},
+		{"Effective date: 2022-11-30", "2022-11-30"},
+		{"Revised on Sept. 3, 2021", "2021-09-03"},
+		{"Updated May 2020", "2020-05-01"},
+		{"Effective 2019-01-01. Last updated: 2023-06-01", "2023-06-01"},
+		{"Last updated: 03/04/2024", ""},
+		{"We may update this policy from time to time.", ""},
+	}
+	for _, tc := range cases {
+		got, ok := parseLegalUpdatedDate(tc.in)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("parseLegalUpdatedDate(%q) = %s, want no date", tc.in, got.Format("2006-01-02"))
+			}
+			continue
+		}
+		if !ok || got.Format("2006-01-02") != tc.want {
+			t.Errorf("parseLegalUpdatedDate(%q) = %s (%v), want %s", tc.in, got.Format("2006-01-02"), ok, tc.want)
+		}
+	}
+}
+
+func TestLegalFreshnessCheck_Age(t *testing.T) {
+	cfg := &config.PreflightConfig{
+		Stack:  "static",
+		Checks: config.ChecksConfig{LegalFreshness: &config.LegalFreshnessConfig{Enabled: true, MaxAgeDays: 90}},
+	}
+	date := func(daysAgo int) string {
+		return time.Now().AddDate(0, 0, -daysAgo).Format("January 2, 2006")
+	}
+
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{"no legal pages", map[string]string{"index.html": "<html></html>"}, true},
+		{"fresh", map[string]string{"privacy.html": "Last updated: " + date(10)}, true},
+		{"stale", map[string]string{"privacy.html": "Last updated: " + date(400)}, false},
+		{"stale terms", map[string]string{
+			"privacy.html": "Last updated: " + date(10),
+			"terms.md":     "Effective date: " + date(200),
+		}, false},
+		{"undated", map[string]string{"privacy.html": "<h1>Privacy</h1>"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := writeFiles(t, tc.files)
+			res, _ := LegalFreshnessCheck{}.Run(Context{RootDir: root, Config: cfg})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q)", res.Passed, tc.want, res.Message)
+			}
+		})
+	}
+}
+
+// A tracker committed after the policy's date must be flagged even though
+// the policy itself is within the age threshold.
+func TestLegalFreshnessCheck_TrackerAddedAfterPolicy(t *testing.T) {
+	root := t.TempDir()
+	initGitRepo(t, root)
+	commitAt := func(when time.Time) {
+		t.Helper()
+		gitCommit(t, root, ".")
+		amend := exec.Command("git", "-C", root, "commit", "--amend", "--no-edit", "--date", when.Format(time.RFC3339))
+		amend.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+when.Format(time.RFC3339))
+		if out, err := amend.CombinedOutput(); err != nil {
+			t.Fatalf("git commit --amend: %v\n%s", err, out)
+		}
+	}
+
+	policyDate := time.Now().AddDate(0, 0, -30)
+	writeFile(t, root, "privacy.html", "Last updated: "+policyDate.Format("2006-01-02"))
+	writeFile(t, root, "index.html", "<html></html>")
+	commitAt(policyDate.AddDate(0, 0, -5))
+
+	writeFile(t, root, "index.html", `<script src="https://connect.facebook.net/en_US/fbevents.js"></script>`)
+	commitAt(time.Now())
+
+	cfg := &config.PreflightConfig{
+		Stack:  "static",
+		Checks: config.ChecksConfig{LegalFreshness: &config.LegalFreshnessConfig{Enabled: true}},
+	}
+	res, _ := LegalFreshnessCheck{}.Run(Context{RootDir: root, Config: cfg})
+	if res.Passed || !strings.Contains(res.Message, "Meta Pixel") {
+		t.Errorf("expected Meta Pixel flagged as added after the policy, got Passed=%v %q", res.Passed, res.Message)
+	}
+}
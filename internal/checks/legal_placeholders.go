@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// legalPlaceholderPatterns fingerprint unfinished policy-generator
+// boilerplate. Each entry is specific enough that it never appears in a
+// finished policy.
+var legalPlaceholderPatterns = []struct {
+	label   string
+	pattern *regexp.Regexp
+}{
+	{"bracketed placeholder", regexp.MustCompile(`(?i)\[\s*(your\s+)?(company|business|website|site|app|organi[sz]ation|legal\s+entity|owner)(\s+(name|url|address))?\s*\]`)},
+	{"bracketed placeholder", regexp.MustCompile(`(?i)\[\s*(insert|enter|add|your)\b[^\]]{0,40}\]`)},
+	{"bracketed placeholder", regexp.MustCompile(`(?i)\[\s*(date|email(\s+address)?|address|phone(\s+number)?|state|country|jurisdiction|city)\s*\]`)},
+	{"\"Your Company\"", regexp.MustCompile(`\b(Your Company|YOUR COMPANY)( Name| NAME)?\b`)},
+	{"lorem ipsum", regexp.MustCompile(`(?i)lorem\s+ipsum`)},
+	{"unfilled jurisdiction", regexp.MustCompile(`(?i)(laws|courts)\s+of\s+(the\s+(state|country|province)\s+of\s+)?(_{3,}|\.{4,}|X{3,}|\(\s*\))`)},
+	// Inline only, so a Markdown "______" horizontal rule doesn't match.
+	{"blank to fill in", regexp.MustCompile(`\w[ \t]+_{5,}|_{5,}[ \t]+\w`)},
+	{"example contact address", regexp.MustCompile(`(?i)\b[\w.+-]+@(example\.(com|org|net)|yourcompany\.com|yourdomain\.com|domain\.com)\b`)},
+}
+
+// LegalPlaceholdersCheck flags privacy policies and terms that still
+// contain template placeholders ("[Company Name]", lorem ipsum, unfilled
+// jurisdiction blanks) from the generator they were copied out of.
+type LegalPlaceholdersCheck struct{}
+
+func (c LegalPlaceholdersCheck) ID() string {
+	return "legal_placeholders"
+}
+
+func (c LegalPlaceholdersCheck) Title() string {
+	return "Legal page placeholders"
+}
+
+func (c LegalPlaceholdersCheck) Run(ctx Context) (CheckResult, error) {
+	pages := findLegalPages(ctx)
+	if len(pages) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No privacy policy or terms found, skipping",
+		}, nil
+	}
+
+	var affected []string
+	var details []string
+	for _, page := range pages {
+		found := findLegalPlaceholders(page.content)
+		if len(found) == 0 {
+			continue
+		}
+		affected = append(affected, page.name)
+		for _, f := range found {
+			details = append(details, fmt.Sprintf("%s (%s): %s", page.name, page.location, f))
+		}
+	}
+
+	if len(affected) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No template placeholders in legal pages",
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityWarn,
+		Passed:   false,
+		Message:  "Unfinished template placeholders in " + strings.Join(affected, " and "),
+		Suggestions: []string{
+			"Replace every placeholder with your legal entity name, contact details and governing jurisdiction",
+			"Have the final text reviewed; a half-filled generator template offers no legal protection",
+		},
+		Details: details,
+	}, nil
+}
+
+// findLegalPlaceholders returns "<label>: <matched text>" for each distinct
+// placeholder in content, in pattern order.
+func findLegalPlaceholders(content string) []string {
+	text := htmlText(content)
+
+	var found []string
+	seen := make(map[string]bool)
+	// covered holds the spans already reported, so text matched by a
+	// specific pattern isn't reported again by a generic one (a
+	// jurisdiction blank is also a "blank to fill in").
+	var covered [][]int
+	for _, p := range legalPlaceholderPatterns {
+	matches:
+		for _, loc := range p.pattern.FindAllStringIndex(text, -1) {
+			for _, c := range covered {
+				if loc[0] < c[1] && c[0] < loc[1] {
+					continue matches
+				}
+			}
+			covered = append(covered, loc)
+			m := strings.Join(strings.Fields(text[loc[0]:loc[1]]), " ")
+			if seen[m] {
+				continue
+			}
+			seen[m] = true
+			found = append(found, fmt.Sprintf("%s: %q", p.label, m))
+		}
+	}
+	return found
+}
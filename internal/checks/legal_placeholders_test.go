@@ -0,0 +1,48 @@
+package checks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+)
+
+func TestLegalPlaceholdersCheck(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{"no legal pages", map[string]string{"index.html": "<html></html>"}, true},
+		{"finished policy", map[string]string{
+			"privacy.html": "<p>Acme Ltd (\"we\") operates acme.io. Governed by the laws of England and Wales.</p>\n\n______\n",
+		}, true},
+		{"company placeholder", map[string]string{"privacy.html": "<p>[Company Name] operates this website.</p>"}, false},
+		{"your company", map[string]string{"terms.md": "These terms are between you and Your Company Inc."}, false},
+		{"lorem ipsum", map[string]string{"privacy.md": "Lorem ipsum dolor sit amet."}, false},
+		{"jurisdiction blank", map[string]string{"terms.html": "Governed by the laws of the State of ________."}, false},
+		{"insert placeholder", map[string]string{"privacy.md": "Contact us at [insert email]."}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := checktest.NewProject(t, tc.files)
+			p.Config.Stack = "static"
+			res := p.Run(checks.LegalPlaceholdersCheck{})
+			if res.Passed != tc.want {
+				t.Errorf("Passed = %v, want %v (%q, %v)", res.Passed, tc.want, res.Message, res.Details)
+			}
+		})
+	}
+}
+
+func TestLegalPlaceholdersReportsEachSpanOnce(t *testing.T) {
+	// A jurisdiction blank also matches the generic "blank to fill in"
+	// pattern; it's reported once, under the more specific label.
+	p := checktest.NewProject(t, map[string]string{"terms.html": "Governed by the laws of the State of ________."})
+	p.Config.Stack = "static"
+	res := p.Run(checks.LegalPlaceholdersCheck{})
+	if len(res.Details) != 1 || !strings.Contains(res.Details[0], "unfilled jurisdiction") {
+		t.Errorf("details = %q, want one unfilled jurisdiction finding", res.Details)
+	}
+}
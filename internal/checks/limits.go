@@ -0,0 +1,105 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// Budget caps what one scan may use, so a pathological repo (millions of
+// files, a check that never returns) can't tie up a shared CI runner. Zero
+// fields mean no limit.
+type Budget struct {
+	// Ctx stops file walks once it's done, e.g. at the scan deadline.
+	Ctx context.Context
+	// MaxFiles is the total number of files the file-scanning checks may
+	// visit across the whole scan.
+	MaxFiles int
+	// MaxConcurrency is the most goroutines a check may fan out to at once.
+	MaxConcurrency int
+}
+
+// scanBudget is the installed Budget and what has been drawn from it.
+// It's package state rather than a Context field because the file walks
+// live in helpers that only get a root directory.
+type scanBudget struct {
+	Budget
+	files        atomic.Int64
+	filesReached atomic.Bool
+	workers      chan struct{}
+}
+
+var activeBudget atomic.Pointer[scanBudget]
+
+// SetBudget installs b for the checks run from now on and resets the file
+// count. The scan runner calls it once before running checks.
+func SetBudget(b Budget) {
+	sb := &scanBudget{Budget: b}
+	if b.MaxConcurrency > 0 {
+		sb.workers = make(chan struct{}, b.MaxConcurrency)
+	}
+	activeBudget.Store(sb)
+}
+
+// FileLimitReached reports whether a walk stopped early because the scan
+// used up its MaxFiles, in which case file-based results are partial.
+func FileLimitReached() bool {
+	b := activeBudget.Load()
+	return b != nil && b.filesReached.Load()
+}
+
+// AcquireWorker blocks until the scan may start another concurrent task
+// and returns the func that gives the slot back. Call it before the go
+// statement so MaxConcurrency bounds goroutines, not just work.
+func AcquireWorker() (release func()) {
+	b := activeBudget.Load()
+	if b == nil || b.workers == nil {
+		return func() {}
+	}
+	b.workers <- struct{}{}
+	return func() { <-b.workers }
+}
+
+// allowFile counts one file against the budget. It returns false once the
+// file limit is used up or the budget's context is done; walks then stop.
+func allowFile(isFile bool) bool {
+	b := activeBudget.Load()
+	if b == nil {
+		return true
+	}
+	if b.Ctx != nil && b.Ctx.Err() != nil {
+		return false
+	}
+	if !isFile || b.MaxFiles <= 0 {
+		return true
+	}
+	if b.files.Add(1) > int64(b.MaxFiles) {
+		b.filesReached.Store(true)
+		return false
+	}
+	return true
+}
+
+// walk is filepath.Walk drawing on the scan's file budget. Checks use it
+// instead of filepath.Walk.
+func walk(root string, fn filepath.WalkFunc) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if !allowFile(info != nil && info.Mode().IsRegular()) {
+			return filepath.SkipAll
+		}
+		return fn(path, info, err)
+	})
+}
+
+// walkDir is filepath.WalkDir drawing on the scan's file budget. Checks
+// use it instead of filepath.WalkDir.
+func walkDir(root string, fn fs.WalkDirFunc) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if !allowFile(d != nil && d.Type().IsRegular()) {
+			return filepath.SkipAll
+		}
+		return fn(path, d, err)
+	})
+}
@@ -0,0 +1,113 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setTestBudget(t *testing.T, b Budget) {
+	t.Helper()
+	SetBudget(b)
+	t.Cleanup(func() { activeBudget.Store(nil) })
+}
+
+// numberedFiles returns n files spread over a few subdirectories, for
+// writeFiles.
+func numberedFiles(n int) map[string]string {
+	files := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		files[fmt.Sprintf("d%d/f%d.txt", i%3, i)] = "x"
+	}
+	return files
+}
+
+func TestWalkFileLimit(t *testing.T) {
+	dir := writeFiles(t, numberedFiles(10))
+
+	// No budget installed: every file is visited.
+	count := func() int {
+		n := 0
+		_ = walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				n++
+			}
+			return nil
+		})
+		return n
+	}
+	if n := count(); n != 10 {
+		t.Fatalf("unlimited walk visited %d files, want 10", n)
+	}
+
+	// The limit is shared by every walk in the scan.
+	setTestBudget(t, Budget{MaxFiles: 7})
+	if n := count(); n != 7 {
+		t.Errorf("first walk visited %d files, want 7", n)
+	}
+	if !FileLimitReached() {
+		t.Error("FileLimitReached() = false after the limit was hit")
+	}
+	n := 0
+	_ = walkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			n++
+		}
+		return nil
+	})
+	if n != 0 {
+		t.Errorf("walk after the limit visited %d files, want 0", n)
+	}
+}
+
+func TestWalkStopsAtDeadline(t *testing.T) {
+	dir := writeFiles(t, numberedFiles(5))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	setTestBudget(t, Budget{Ctx: ctx})
+
+	visited := 0
+	_ = walk(dir, func(path string, info os.FileInfo, err error) error {
+		visited++
+		return nil
+	})
+	if visited != 0 {
+		t.Errorf("walk visited %d entries after the deadline, want 0", visited)
+	}
+	if FileLimitReached() {
+		t.Error("FileLimitReached() = true; a deadline isn't a file limit")
+	}
+}
+
+func TestAcquireWorkerBoundsConcurrency(t *testing.T) {
+	setTestBudget(t, Budget{MaxConcurrency: 2})
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		release := AcquireWorker()
+		go func() {
+			defer wg.Done()
+			defer release()
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", p)
+	}
+}
@@ -0,0 +1,399 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/config"
+	"github.com/preflightsh/preflight/internal/netutil"
+)
+
+// check-host.net runs HTTP checks from nodes around the world without an
+// API key. Variables so tests can point them at an httptest server.
+var (
+	checkHostAPI  = "https://check-host.net"
+	checkHostPoll = 2 * time.Second
+	// checkHostWait bounds how long results are polled for; nodes that
+	// haven't answered by then are reported as inconclusive.
+	checkHostWait = 30 * time.Second
+	// remoteClient makes the check-host.net and probe requests. Those
+	// answer in seconds rather than milliseconds, so they get their own
+	// client instead of the scan's short-timeout ctx.Client.
+	remoteClient = func(userAgent string) *http.Client {
+		return netutil.Polite(netutil.SafeHTTPClient(remoteTimeout), userAgent)
+	}
+)
+
+// remoteTimeout bounds each request to check-host.net or a probe.
+const remoteTimeout = 15 * time.Second
+
+// continentOrder is the order regions are picked and reported in.
+var continentOrder = []string{"Europe", "North America", "Asia", "South America", "Oceania", "Africa"}
+
+// nodeContinents maps the country codes check-host.net nodes report to a
+// continent. Nodes in other countries aren't used.
+var nodeContinents = map[string]string{
+	"at": "Europe", "bg": "Europe", "ch": "Europe", "cz": "Europe", "de": "Europe",
+	"es": "Europe", "fi": "Europe", "fr": "Europe", "gb": "Europe", "it": "Europe",
+	"lt": "Europe", "nl": "Europe", "pl": "Europe", "pt": "Europe", "ro": "Europe",
+	"rs": "Europe", "se": "Europe", "ua": "Europe",
+	"us": "North America", "ca": "North America", "mx": "North America",
+	"jp": "Asia", "sg": "Asia", "hk": "Asia", "in": "Asia", "kr": "Asia",
+	"id": "Asia", "vn": "Asia", "tw": "Asia", "ae": "Asia", "il": "Asia", "tr": "Asia",
+	"br": "South America", "ar": "South America", "cl": "South America",
+	"au": "Oceania", "nz": "Oceania",
+	"za": "Africa", "ng": "Africa", "ke": "Africa",
+}
+
+// MultiRegionCheck requests the production URL from several continents,
+// through check-host.net or the user's own probe endpoints, to catch
+// geo-blocking and CDN misconfiguration that a request from the CI
+// runner's region can't see.
+type MultiRegionCheck struct{}
+
+func (c MultiRegionCheck) ID() string {
+	return "multi_region"
+}
+
+func (c MultiRegionCheck) Title() string {
+	return "Multi-region reachability"
+}
+
+// regionResult is one region's view of the production URL. A probe that
+// couldn't run (checker down, no answer in time) is inconclusive rather
+// than a failure of the site.
+type regionResult struct {
+	region       string
+	reachable    bool
+	inconclusive bool
+	detail       string
+}
+
+func (c MultiRegionCheck) Run(ctx Context) (CheckResult, error) {
+	cfg := ctx.Config.Checks.MultiRegion
+	if cfg == nil || !cfg.Enabled {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Multi-region probe not enabled, skipping",
+		}, nil
+	}
+	target := ctx.Config.URLs.Production
+	if target == "" || IsLocalURL(target) {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No public production URL configured, skipping",
+		}, nil
+	}
+	if !strings.Contains(target, "://") {
+		target = "https://" + target
+	}
+
+	client := remoteClient(ctx.UserAgent)
+	var results []regionResult
+	var err error
+	if len(cfg.Probes) > 0 {
+		results = runRegionProbes(ctx, client, cfg.Probes, target)
+	} else {
+		results, err = runCheckHost(ctx, client, target)
+	}
+	if err != nil {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  fmt.Sprintf("Couldn't run check-host.net probes (%v), skipping", err),
+		}, nil
+	}
+
+	var reachable, unreachable, details []string
+	for _, r := range results {
+		switch {
+		case r.inconclusive:
+			details = append(details, fmt.Sprintf("%s: inconclusive (%s)", r.region, r.detail))
+		case r.reachable:
+			reachable = append(reachable, r.region)
+			details = append(details, fmt.Sprintf("%s: %s", r.region, r.detail))
+		default:
+			unreachable = append(unreachable, r.region)
+			details = append(details, fmt.Sprintf("%s: unreachable (%s)", r.region, r.detail))
+		}
+	}
+
+	if len(unreachable) > 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  fmt.Sprintf("Production URL unreachable from %s", strings.Join(unreachable, ", ")),
+			Suggestions: []string{
+				"Check CDN and WAF geo-blocking rules and country allowlists",
+				"Verify DNS resolves the same way from other regions (GeoDNS, CDN edge config)",
+			},
+			Details: details,
+		}, nil
+	}
+	if len(reachable) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No probe returned a result, skipping",
+			Details:  details,
+		}, nil
+	}
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityInfo,
+		Passed:   true,
+		Message:  fmt.Sprintf("Reachable from %s", strings.Join(reachable, ", ")),
+		Details:  details,
+	}, nil
+}
+
+// runRegionProbes asks each user-provided probe endpoint to fetch target.
+// A probe is called as GET <url>?url=<target> and answers with JSON:
+// {"status": 200} on a response, {"error": "..."} when the fetch failed.
+func runRegionProbes(ctx Context, client *http.Client, probes []config.RegionProbeConfig, target string) []regionResult {
+	results := make([]regionResult, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		release := AcquireWorker()
+		go func(i int, p config.RegionProbeConfig) {
+			defer wg.Done()
+			defer release()
+			results[i] = runRegionProbe(ctx, client, p, target)
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+func runRegionProbe(ctx Context, client *http.Client, p config.RegionProbeConfig, target string) regionResult {
+	res := regionResult{region: p.Name}
+	if res.region == "" {
+		res.region = p.URL
+	}
+
+	probeURL, err := url.Parse(p.URL)
+	if err != nil {
+		res.inconclusive, res.detail = true, "invalid probe URL"
+		return res
+	}
+	q := probeURL.Query()
+	q.Set("url", target)
+	probeURL.RawQuery = q.Encode()
+
+	var body struct {
+		Status int    `json:"status"`
+		Error  string `json:"error"`
+	}
+	if err := getJSON(ctx, client, probeURL.String(), &body); err != nil {
+		res.inconclusive, res.detail = true, err.Error()
+		return res
+	}
+	switch {
+	case body.Error != "":
+		res.detail = body.Error
+	case body.Status == 0:
+		res.inconclusive, res.detail = true, "probe returned no status"
+	default:
+		res.reachable = body.Status < 400
+		res.detail = fmt.Sprintf("HTTP %d", body.Status)
+	}
+	return res
+}
+
+// runCheckHost starts a check-host.net HTTP check from one node per
+// continent and polls for the results.
+func runCheckHost(ctx Context, client *http.Client, target string) ([]regionResult, error) {
+	nodes, err := checkHostNodes(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := checkHostRun(ctx, client, "http", target, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]regionResult, 0, len(nodes))
+	for _, n := range nodes {
+		res := regionResult{region: n.region()}
+		res.reachable, res.inconclusive, res.detail = parseCheckHostAnswer(answers[n.name])
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// checkHostNode is a check-host.net node picked to represent a continent.
+type checkHostNode struct {
+	name      string
+	continent string
+	location  []string
+}
+
+func (n checkHostNode) region() string {
+	return fmt.Sprintf("%s (%s)", n.continent, strings.Join(nodeLocation(n.location), ", "))
+}
+
+// checkHostNodes picks one check-host.net node per continent, in
+// continentOrder.
+func checkHostNodes(ctx Context, client *http.Client) ([]checkHostNode, error) {
+	var hosts struct {
+		Nodes map[string]struct {
+			Location []string `json:"location"`
+		} `json:"nodes"`
+	}
+	if err := getJSON(ctx, client, checkHostAPI+"/nodes/hosts", &hosts); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(hosts.Nodes))
+	for name := range hosts.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	nodeFor := make(map[string]string)
+	for _, name := range names {
+		loc := hosts.Nodes[name].Location
+		if len(loc) == 0 {
+			continue
+		}
+		continent := nodeContinents[strings.ToLower(loc[0])]
+		if continent != "" && nodeFor[continent] == "" {
+			nodeFor[continent] = name
+		}
+	}
+
+	var nodes []checkHostNode
+	for _, continent := range continentOrder {
+		if name := nodeFor[continent]; name != "" {
+			nodes = append(nodes, checkHostNode{name: name, continent: continent, location: hosts.Nodes[name].Location})
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no check-host.net nodes available")
+	}
+	return nodes, nil
+}
+
+// checkHostRun starts a check-host.net check of kind (http, tcp) against
+// host from nodes and polls until every node has answered or
+// checkHostWait runs out. It returns each node's raw answer, null for
+// nodes that didn't finish.
+func checkHostRun(ctx Context, client *http.Client, kind, host string, nodes []checkHostNode) (map[string]json.RawMessage, error) {
+	q := url.Values{"host": {host}}
+	for _, n := range nodes {
+		q.Add("node", n.name)
+	}
+	var started struct {
+		OK        int    `json:"ok"`
+		RequestID string `json:"request_id"`
+	}
+	if err := getJSON(ctx, client, checkHostAPI+"/check-"+kind+"?"+q.Encode(), &started); err != nil {
+		return nil, err
+	}
+	if started.OK != 1 || started.RequestID == "" {
+		return nil, fmt.Errorf("check-host.net didn't start the check")
+	}
+
+	// Each node's entry is null until it has finished.
+	var answers map[string]json.RawMessage
+	deadline := time.Now().Add(checkHostWait)
+	for {
+		answers = nil
+		if err := getJSON(ctx, client, checkHostAPI+"/check-result/"+url.PathEscape(started.RequestID), &answers); err != nil {
+			return nil, err
+		}
+		pending := false
+		for _, n := range nodes {
+			if a := answers[n.name]; len(a) == 0 || string(a) == "null" {
+				pending = true
+			}
+		}
+		if !pending || time.Now().After(deadline) {
+			return answers, nil
+		}
+		select {
+		case <-ctx.reqContext().Done():
+			return nil, ctx.reqContext().Err()
+		case <-time.After(checkHostPoll):
+		}
+	}
+}
+
+// nodeLocation returns the human-readable part of a node's location
+// (country and city), dropping the country code.
+func nodeLocation(loc []string) []string {
+	if len(loc) <= 1 {
+		return loc
+	}
+	return loc[1:]
+}
+
+// parseCheckHostAnswer reads one node's check-http result:
+// [[success, seconds, message, status code, ip]], or null while pending.
+func parseCheckHostAnswer(raw json.RawMessage) (reachable, inconclusive bool, detail string) {
+	var rows [][]any
+	if len(raw) == 0 || string(raw) == "null" {
+		return false, true, "no answer in time"
+	}
+	if err := json.Unmarshal(raw, &rows); err != nil || len(rows) == 0 || len(rows[0]) < 3 {
+		return false, true, "unexpected check-host.net result"
+	}
+	row := rows[0]
+	success, _ := row[0].(float64)
+	message, _ := row[2].(string)
+	code := ""
+	if len(row) > 3 {
+		code, _ = row[3].(string)
+	}
+	if success == 1 {
+		if code != "" {
+			return true, false, "HTTP " + code
+		}
+		return true, false, message
+	}
+	if code != "" {
+		return false, false, fmt.Sprintf("HTTP %s %s", code, message)
+	}
+	return false, false, message
+}
+
+// getJSON GETs rawURL with client and decodes the JSON response.
+func getJSON(ctx Context, client *http.Client, rawURL string, out any) error {
+	reqCtx, cancel := context.WithTimeout(ctx.reqContext(), remoteTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned HTTP %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, netutil.MaxResponseBody)).Decode(out)
+}
@@ -0,0 +1,132 @@
+package checks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestMultiRegionCheck_CheckHost(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes/hosts":
+			w.Write([]byte(`{"nodes":{
+				"de1.node.check-host.net":{"location":["de","Germany","Frankfurt"]},
+				"fr1.node.check-host.net":{"location":["fr","France","Paris"]},
+				"us1.node.check-host.net":{"location":["us","USA","Los Angeles"]},
+				"sg1.node.check-host.net":{"location":["sg","Singapore","Singapore"]},
+				"xx1.node.check-host.net":{"location":["xx","Nowhere","Nowhere"]}
+			}}`))
+		case "/check-http":
+			nodes := r.URL.Query()["node"]
+			if r.URL.Query().Get("host") != "https://example.com" || strings.Join(nodes, ",") != "de1.node.check-host.net,us1.node.check-host.net,sg1.node.check-host.net" {
+				t.Errorf("check-http query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"ok":1,"request_id":"abc123"}`))
+		case "/check-result/abc123":
+			polls++
+			if polls == 1 {
+				w.Write([]byte(`{"de1.node.check-host.net":[[1,0.1,"OK","200","93.184.216.34"]],"us1.node.check-host.net":null,"sg1.node.check-host.net":null}`))
+				return
+			}
+			w.Write([]byte(`{"de1.node.check-host.net":[[1,0.1,"OK","200","93.184.216.34"]],
+				"us1.node.check-host.net":[[1,0.2,"OK","301","93.184.216.34"]],
+				"sg1.node.check-host.net":[[0,0.3,"Forbidden","403","93.184.216.34"]]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	useRemoteClient(t, srv)
+	oldAPI, oldPoll := checkHostAPI, checkHostPoll
+	checkHostAPI, checkHostPoll = srv.URL, time.Millisecond
+	defer func() { checkHostAPI, checkHostPoll = oldAPI, oldPoll }()
+
+	cfg := &config.PreflightConfig{
+		URLs:   config.URLConfig{Production: "https://example.com"},
+		Checks: config.ChecksConfig{MultiRegion: &config.MultiRegionConfig{Enabled: true}},
+	}
+	res, err := MultiRegionCheck{}.Run(Context{Config: cfg})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Passed || res.Message != "Production URL unreachable from Asia (Singapore, Singapore)" {
+		t.Errorf("Passed = %v, Message %q; want Asia reported unreachable", res.Passed, res.Message)
+	}
+	if polls != 2 || len(res.Details) != 3 || res.Details[1] != "North America (USA, Los Angeles): HTTP 301" {
+		t.Errorf("polls = %d, Details = %q", polls, res.Details)
+	}
+}
+
+func TestMultiRegionCheck_Probes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("url") != "https://example.com" {
+			t.Errorf("probe url param = %q", r.URL.Query().Get("url"))
+		}
+		switch r.URL.Path {
+		case "/eu":
+			w.Write([]byte(`{"status":200}`))
+		case "/ap":
+			w.Write([]byte(`{"error":"connection reset"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	useRemoteClient(t, srv)
+
+	probes := []config.RegionProbeConfig{
+		{Name: "eu-west", URL: srv.URL + "/eu"},
+		{Name: "ap-south", URL: srv.URL + "/ap"},
+		{Name: "us-east", URL: srv.URL + "/down"},
+	}
+	cfg := &config.PreflightConfig{
+		URLs:   config.URLConfig{Production: "example.com"},
+		Checks: config.ChecksConfig{MultiRegion: &config.MultiRegionConfig{Enabled: true, Probes: probes}},
+	}
+	res, _ := MultiRegionCheck{}.Run(Context{Config: cfg})
+	if res.Passed || res.Message != "Production URL unreachable from ap-south" {
+		t.Errorf("Passed = %v, Message %q; want ap-south unreachable", res.Passed, res.Message)
+	}
+	if len(res.Details) != 3 || !strings.HasPrefix(res.Details[2], "us-east: inconclusive") {
+		t.Errorf("a failing probe should be inconclusive, not a site failure: %q", res.Details)
+	}
+}
+
+func TestMultiRegionCheck_SlowProbe(t *testing.T) {
+	// A probe in a far region can take longer than the scan client's
+	// timeout; that's a slow answer, not an unreachable site.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2500 * time.Millisecond)
+		w.Write([]byte(`{"status":200}`))
+	}))
+	defer srv.Close()
+	useRemoteClient(t, srv)
+
+	cfg := &config.PreflightConfig{
+		URLs: config.URLConfig{Production: "https://example.com"},
+		Checks: config.ChecksConfig{MultiRegion: &config.MultiRegionConfig{
+			Enabled: true,
+			Probes:  []config.RegionProbeConfig{{Name: "ap-southeast", URL: srv.URL}},
+		}},
+	}
+	scanClient := &http.Client{Timeout: 2 * time.Second}
+	res, _ := MultiRegionCheck{}.Run(Context{Config: cfg, Client: scanClient})
+	if !res.Passed || res.Message != "Reachable from ap-southeast" {
+		t.Errorf("Passed = %v, Message %q, Details %q; want a slow probe to count", res.Passed, res.Message, res.Details)
+	}
+}
+
+// useRemoteClient points remoteClient at srv for the test, since
+// SafeHTTPClient refuses httptest's loopback address.
+func useRemoteClient(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	old := remoteClient
+	remoteClient = func(string) *http.Client { return srv.Client() }
+	t.Cleanup(func() { remoteClient = old })
+}
@@ -83,7 +83,7 @@ func (c OGTwitterCheck) Run(ctx Context) (CheckResult, error) {
 		generateMetadataPattern := regexp.MustCompile(`(?s)export\s+(async\s+)?function\s+generateMetadata`)
 		metadataExportPattern := regexp.MustCompile(`(?s)export\s+(const|let|var)\s+metadata\s*[=:]`)
 
-		_ = filepath.Walk(appDir, func(path string, info os.FileInfo, err error) error {
+		_ = walk(appDir, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				if info != nil && info.IsDir() {
 					return filepath.SkipDir
@@ -126,13 +126,16 @@ func (c OGTwitterCheck) Run(ctx Context) (CheckResult, error) {
 		}
 	}
 
-	// OG and Twitter card elements
-	checks := map[string]*regexp.Regexp{
-		"og:image":      regexp.MustCompile(`(?i)<meta[^>]+property=["']og:image["'][^>]*>`),
-		"og:url":        regexp.MustCompile(`(?i)<meta[^>]+property=["']og:url["'][^>]*>`),
-		"og:type":       regexp.MustCompile(`(?i)<meta[^>]+property=["']og:type["'][^>]*>`),
-		"twitter:card":  regexp.MustCompile(`(?i)<meta[^>]+name=["']twitter:card["'][^>]*>`),
-		"twitter:image": regexp.MustCompile(`(?i)<meta[^>]+name=["']twitter:image["'][^>]*>`),
+	// OG and Twitter card elements, in the order they're reported
+	checks := []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		{"og:image", regexp.MustCompile(`(?i)<meta[^>]+property=["']og:image["'][^>]*>`)},
+		{"og:url", regexp.MustCompile(`(?i)<meta[^>]+property=["']og:url["'][^>]*>`)},
+		{"og:type", regexp.MustCompile(`(?i)<meta[^>]+property=["']og:type["'][^>]*>`)},
+		{"twitter:card", regexp.MustCompile(`(?i)<meta[^>]+name=["']twitter:card["'][^>]*>`)},
+		{"twitter:image", regexp.MustCompile(`(?i)<meta[^>]+name=["']twitter:image["'][^>]*>`)},
 	}
 
 	// Alternate patterns for Next.js/React metadata API
@@ -162,8 +165,9 @@ func (c OGTwitterCheck) Run(ctx Context) (CheckResult, error) {
 	ogImageURL := extractMetaContent(contentStr, `property=["']og:image["']`)
 	twitterImageURL := extractMetaContent(contentStr, `name=["']twitter:image["']`)
 
-	for name, pattern := range checks {
-		matched := pattern.MatchString(contentStr)
+	for _, check := range checks {
+		name := check.name
+		matched := check.pattern.MatchString(contentStr)
 
 		// Try alternate patterns
 		if !matched {
@@ -217,6 +221,23 @@ func (c OGTwitterCheck) Run(ctx Context) (CheckResult, error) {
 		}
 	}
 
+	// Image URLs emitted at runtime only exist in the rendered page.
+	if ogImageURL == "" || twitterImageURL == "" {
+		page := ctx.PageHTMLProduction
+		if page == "" {
+			page = ctx.PageHTML
+		}
+		if page != "" {
+			doc := parseRenderedHTML(page)
+			if ogImageURL == "" {
+				ogImageURL = doc.metaContent("og:image")
+			}
+			if twitterImageURL == "" {
+				twitterImageURL = doc.metaContent("twitter:image")
+			}
+		}
+	}
+
 	// Also check for opengraph-image and twitter-image files in app directory
 	ogImageFiles := []string{
 		"app/opengraph-image.png",
@@ -263,7 +284,7 @@ func (c OGTwitterCheck) Run(ctx Context) (CheckResult, error) {
 		if _, err := os.Stat(dirPath); err != nil {
 			continue
 		}
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				if info != nil && info.IsDir() {
 					return filepath.SkipDir
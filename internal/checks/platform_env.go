@@ -0,0 +1,474 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/preflightsh/preflight/internal/fsutil"
+	"github.com/preflightsh/preflight/internal/netutil"
+)
+
+// API endpoints for each hosting platform. Variables so tests can point
+// them at an httptest server.
+var (
+	vercelAPI  = "https://api.vercel.com"
+	netlifyAPI = "https://api.netlify.com"
+	flyAPI     = "https://api.fly.io/graphql"
+	herokuAPI  = "https://api.heroku.com"
+)
+
+// platformManagedPrefixes are variables the platform injects on its own;
+// they never belong in .env.example, so they aren't reported as extra.
+var platformManagedPrefixes = []string{"VERCEL_", "NETLIFY_", "FLY_", "HEROKU_", "NEXT_RUNTIME"}
+
+// envPlatform is a hosting platform whose production environment
+// variables can be listed through its API.
+type envPlatform struct {
+	name     string
+	tokenEnv string
+	detect   func(rootDir string) bool
+	// fetch returns the names of the variables set for production.
+	fetch func(ctx Context, token string) (map[string]bool, error)
+}
+
+var envPlatforms = []envPlatform{
+	{
+		name:     "vercel",
+		tokenEnv: "VERCEL_TOKEN",
+		detect: func(rootDir string) bool {
+			return fsutil.FileExists(rootDir, "vercel.json") || fsutil.FileExists(rootDir, ".vercel/project.json")
+		},
+		fetch: fetchVercelEnv,
+	},
+	{
+		name:     "netlify",
+		tokenEnv: "NETLIFY_AUTH_TOKEN",
+		detect: func(rootDir string) bool {
+			return fsutil.FileExists(rootDir, "netlify.toml") || fsutil.FileExists(rootDir, ".netlify/state.json")
+		},
+		fetch: fetchNetlifyEnv,
+	},
+	{
+		name:     "fly",
+		tokenEnv: "FLY_API_TOKEN",
+		detect: func(rootDir string) bool {
+			return fsutil.FileExists(rootDir, "fly.toml")
+		},
+		fetch: fetchFlyEnv,
+	},
+	{
+		name:     "heroku",
+		tokenEnv: "HEROKU_API_KEY",
+		detect:   detectHeroku,
+		fetch:    fetchHerokuEnv,
+	},
+}
+
+// PlatformEnvCheck extends envParity to the hosting platform: it lists the
+// production variables defined on Vercel, Netlify, Fly.io or Heroku through
+// their API and compares them with the ones .env.example requires.
+type PlatformEnvCheck struct{}
+
+func (c PlatformEnvCheck) ID() string {
+	return "platform_env"
+}
+
+func (c PlatformEnvCheck) Title() string {
+	return "Platform environment variables"
+}
+
+func (c PlatformEnvCheck) Run(ctx Context) (CheckResult, error) {
+	cfg := ctx.Config.Checks.EnvParity
+	if cfg == nil || cfg.Platform == "none" {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "Platform env comparison disabled, skipping",
+		}, nil
+	}
+
+	platform, ok := detectEnvPlatform(ctx.RootDir, cfg.Platform)
+	if !ok {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No supported hosting platform detected, skipping",
+		}, nil
+	}
+
+	token := os.Getenv(platform.tokenEnv)
+	if token == "" {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  fmt.Sprintf("%s detected but %s is not set, skipping", platform.name, platform.tokenEnv),
+		}, nil
+	}
+
+	required, err := parseEnvFile(filepath.Join(ctx.RootDir, cfg.ExampleFile))
+	if err != nil {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No " + cfg.ExampleFile + " found (skipped)",
+		}, nil
+	}
+
+	defined, err := platform.fetch(ctx, token)
+	if err != nil {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  fmt.Sprintf("Could not list %s environment variables: %v", platform.name, err),
+			Suggestions: []string{
+				fmt.Sprintf("Check that %s is valid and has read access to this project", platform.tokenEnv),
+			},
+		}, nil
+	}
+
+	var missing, extra []string
+	for key := range required {
+		if !defined[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range defined {
+		if !required[key] && !isPlatformManagedVar(key) {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  fmt.Sprintf("All %d variables in %s are set on %s", len(required), cfg.ExampleFile, platform.name),
+		}, nil
+	}
+
+	var messages []string
+	var suggestions []string
+	if len(missing) > 0 {
+		messages = append(messages, fmt.Sprintf("Missing on %s: %s", platform.name, strings.Join(missing, ", ")))
+		suggestions = append(suggestions, fmt.Sprintf("Set %s in the %s production environment", strings.Join(missing, ", "), platform.name))
+	}
+	if len(extra) > 0 {
+		messages = append(messages, fmt.Sprintf("Set on %s but not in %s: %s", platform.name, cfg.ExampleFile, strings.Join(extra, ", ")))
+		suggestions = append(suggestions, "Document "+strings.Join(extra, ", ")+" in "+cfg.ExampleFile+", or remove them from the platform if unused")
+	}
+
+	return CheckResult{
+		ID:          c.ID(),
+		Title:       c.Title(),
+		Severity:    SeverityWarn,
+		Passed:      false,
+		Message:     strings.Join(messages, "; "),
+		Suggestions: suggestions,
+	}, nil
+}
+
+// detectEnvPlatform returns the configured platform, or the first one whose
+// config files are present when name is empty.
+func detectEnvPlatform(rootDir, name string) (envPlatform, bool) {
+	for _, p := range envPlatforms {
+		if name != "" {
+			if p.name == name {
+				return p, true
+			}
+			continue
+		}
+		if p.detect(rootDir) {
+			return p, true
+		}
+	}
+	return envPlatform{}, false
+}
+
+// detectHeroku looks for heroku.yml, a Heroku app manifest or a Heroku app
+// (HEROKU_APP or a heroku git remote). Render, Railway and Dokku read a
+// Procfile too, so a Procfile alone only counts when Heroku credentials are
+// set and no other Procfile host is configured.
+func detectHeroku(rootDir string) bool {
+	if fsutil.FileExists(rootDir, "heroku.yml") || isHerokuAppJSON(rootDir) || herokuAppName(rootDir) != "" {
+		return true
+	}
+	if !fsutil.FileExists(rootDir, "Procfile") || os.Getenv("HEROKU_API_KEY") == "" {
+		return false
+	}
+	for _, other := range []string{"render.yaml", "railway.json", "railway.toml", "DOKKU_SCALE"} {
+		if fsutil.FileExists(rootDir, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// herokuAppJSONKeys are app.json keys only Heroku's app manifest uses.
+// Expo and React Native keep their own config in an app.json too.
+var herokuAppJSONKeys = []string{"formation", "addons", "buildpacks"}
+
+// isHerokuAppJSON reports whether app.json is a Heroku app manifest.
+func isHerokuAppJSON(rootDir string) bool {
+	data, err := os.ReadFile(filepath.Join(rootDir, "app.json"))
+	if err != nil {
+		return false
+	}
+	var manifest map[string]json.RawMessage
+	if json.Unmarshal(data, &manifest) != nil {
+		return false
+	}
+	for _, key := range herokuAppJSONKeys {
+		if _, ok := manifest[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isPlatformManagedVar(key string) bool {
+	for _, prefix := range platformManagedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// platformAPI performs an authenticated API request and decodes the JSON
+// response into out.
+func platformAPI(ctx Context, method, rawURL, token string, body []byte, headers map[string]string, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx.reqContext(), method, rawURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned HTTP %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, netutil.MaxResponseBody)).Decode(out)
+}
+
+// readJSONFile decodes a small project-local JSON file (e.g. the CLI link
+// state each platform writes) into out.
+func readJSONFile(rootDir, name string, out interface{}) bool {
+	data, err := os.ReadFile(filepath.Join(rootDir, name))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
+
+func fetchVercelEnv(ctx Context, token string) (map[string]bool, error) {
+	var link struct {
+		ProjectID string `json:"projectId"`
+		OrgID     string `json:"orgId"`
+	}
+	readJSONFile(ctx.RootDir, ".vercel/project.json", &link)
+	if id := os.Getenv("VERCEL_PROJECT_ID"); id != "" {
+		link.ProjectID = id
+	}
+	if id := os.Getenv("VERCEL_ORG_ID"); id != "" {
+		link.OrgID = id
+	}
+	if link.ProjectID == "" {
+		return nil, fmt.Errorf("no project ID (run `vercel link` or set VERCEL_PROJECT_ID)")
+	}
+
+	endpoint := vercelAPI + "/v9/projects/" + url.PathEscape(link.ProjectID) + "/env"
+	if strings.HasPrefix(link.OrgID, "team_") {
+		endpoint += "?teamId=" + url.QueryEscape(link.OrgID)
+	}
+	var resp struct {
+		Envs []struct {
+			Key    string   `json:"key"`
+			Target []string `json:"target"`
+		} `json:"envs"`
+	}
+	if err := platformAPI(ctx, http.MethodGet, endpoint, token, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool)
+	for _, env := range resp.Envs {
+		for _, target := range env.Target {
+			if target == "production" {
+				defined[env.Key] = true
+			}
+		}
+	}
+	return defined, nil
+}
+
+func fetchNetlifyEnv(ctx Context, token string) (map[string]bool, error) {
+	var state struct {
+		SiteID string `json:"siteId"`
+	}
+	readJSONFile(ctx.RootDir, ".netlify/state.json", &state)
+	if id := os.Getenv("NETLIFY_SITE_ID"); id != "" {
+		state.SiteID = id
+	}
+	if state.SiteID == "" {
+		return nil, fmt.Errorf("no site ID (run `netlify link` or set NETLIFY_SITE_ID)")
+	}
+
+	var site struct {
+		AccountID string `json:"account_id"`
+	}
+	if err := platformAPI(ctx, http.MethodGet, netlifyAPI+"/api/v1/sites/"+url.PathEscape(state.SiteID), token, nil, nil, &site); err != nil {
+		return nil, err
+	}
+
+	var envs []struct {
+		Key    string `json:"key"`
+		Values []struct {
+			Context string `json:"context"`
+		} `json:"values"`
+	}
+	endpoint := netlifyAPI + "/api/v1/accounts/" + url.PathEscape(site.AccountID) + "/env?site_id=" + url.QueryEscape(state.SiteID)
+	if err := platformAPI(ctx, http.MethodGet, endpoint, token, nil, nil, &envs); err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool)
+	for _, env := range envs {
+		for _, v := range env.Values {
+			if v.Context == "all" || v.Context == "production" {
+				defined[env.Key] = true
+			}
+		}
+	}
+	return defined, nil
+}
+
+var (
+	reFlyApp     = regexp.MustCompile(`(?m)^\s*app\s*=\s*["']([^"']+)["']`)
+	reTOMLHeader = regexp.MustCompile(`^\s*\[`)
+	reTOMLKey    = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*=`)
+)
+
+func fetchFlyEnv(ctx Context, token string) (map[string]bool, error) {
+	content, err := os.ReadFile(filepath.Join(ctx.RootDir, "fly.toml"))
+	if err != nil {
+		return nil, err
+	}
+	m := reFlyApp.FindSubmatch(content)
+	if m == nil {
+		return nil, fmt.Errorf("no app name in fly.toml")
+	}
+
+	query, _ := json.Marshal(map[string]interface{}{
+		"query":     `query($app: String!) { app(name: $app) { secrets { name } } }`,
+		"variables": map[string]string{"app": string(m[1])},
+	})
+	var resp struct {
+		Data struct {
+			App struct {
+				Secrets []struct {
+					Name string `json:"name"`
+				} `json:"secrets"`
+			} `json:"app"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := platformAPI(ctx, http.MethodPost, flyAPI, token, query, nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("%s", resp.Errors[0].Message)
+	}
+
+	defined := make(map[string]bool)
+	for _, s := range resp.Data.App.Secrets {
+		defined[s.Name] = true
+	}
+	// Non-secret variables live in fly.toml's [env] table.
+	inEnv := false
+	for _, line := range strings.Split(string(content), "\n") {
+		if reTOMLHeader.MatchString(line) {
+			inEnv = strings.TrimSpace(line) == "[env]"
+			continue
+		}
+		if km := reTOMLKey.FindStringSubmatch(line); inEnv && km != nil {
+			defined[km[1]] = true
+		}
+	}
+	return defined, nil
+}
+
+var reHerokuRemote = regexp.MustCompile(`heroku\.com[:/]([a-z0-9-]+)\.git`)
+
+// herokuAppName returns the app from HEROKU_APP or the "heroku" git remote.
+func herokuAppName(rootDir string) string {
+	if app := os.Getenv("HEROKU_APP"); app != "" {
+		return app
+	}
+	remote, err := runGit(rootDir, "remote", "get-url", "heroku")
+	if err != nil {
+		return ""
+	}
+	if m := reHerokuRemote.FindStringSubmatch(remote); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func fetchHerokuEnv(ctx Context, token string) (map[string]bool, error) {
+	app := herokuAppName(ctx.RootDir)
+	if app == "" {
+		return nil, fmt.Errorf("no app name (set HEROKU_APP or add a heroku git remote)")
+	}
+
+	var vars map[string]interface{}
+	headers := map[string]string{"Accept": "application/vnd.heroku+json; version=3"}
+	if err := platformAPI(ctx, http.MethodGet, herokuAPI+"/apps/"+url.PathEscape(app)+"/config-vars", token, nil, headers, &vars); err != nil {
+		return nil, err
+	}
+
+	defined := make(map[string]bool)
+	for key := range vars {
+		defined[key] = true
+	}
+	return defined, nil
+}
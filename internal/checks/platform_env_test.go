@@ -0,0 +1,103 @@
+package checks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestPlatformEnvCheck_Vercel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v9/projects/prj_1/env" || r.URL.Query().Get("teamId") != "team_1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"envs":[
+			{"key":"DATABASE_URL","target":["production","preview"]},
+			{"key":"STRIPE_KEY","target":["preview"]},
+			{"key":"LEGACY_FLAG","target":["production"]},
+			{"key":"VERCEL_URL","target":["production"]}
+		]}`))
+	}))
+	defer srv.Close()
+	old := vercelAPI
+	vercelAPI = srv.URL
+	defer func() { vercelAPI = old }()
+
+	t.Setenv("VERCEL_TOKEN", "tok")
+	t.Setenv("VERCEL_PROJECT_ID", "")
+	t.Setenv("VERCEL_ORG_ID", "")
+	root := writeFiles(t, map[string]string{
+		".vercel/project.json": `{"projectId":"prj_1","orgId":"team_1"}`,
+		".env.example":         "DATABASE_URL=\nSTRIPE_KEY=\n",
+	})
+	cfg := &config.PreflightConfig{Checks: config.ChecksConfig{
+		EnvParity: &config.EnvParityConfig{Enabled: true, ExampleFile: ".env.example"},
+	}}
+
+	res, _ := PlatformEnvCheck{}.Run(Context{RootDir: root, Config: cfg, Client: srv.Client()})
+	if res.Passed {
+		t.Fatalf("expected failure, got %q", res.Message)
+	}
+	if !strings.Contains(res.Message, "Missing on vercel: STRIPE_KEY") {
+		t.Errorf("preview-only STRIPE_KEY should be missing in production: %q", res.Message)
+	}
+	if !strings.Contains(res.Message, "LEGACY_FLAG") || strings.Contains(res.Message, "VERCEL_URL") {
+		t.Errorf("want LEGACY_FLAG reported extra and VERCEL_URL ignored: %q", res.Message)
+	}
+}
+
+func TestPlatformEnvCheck_Skips(t *testing.T) {
+	cfg := &config.PreflightConfig{Checks: config.ChecksConfig{
+		EnvParity: &config.EnvParityConfig{Enabled: true, ExampleFile: ".env.example"},
+	}}
+
+	t.Run("no platform", func(t *testing.T) {
+		root := writeFiles(t, map[string]string{".env.example": "A=\n"})
+		res, _ := PlatformEnvCheck{}.Run(Context{RootDir: root, Config: cfg})
+		if !res.Passed || !strings.Contains(res.Message, "skipping") {
+			t.Errorf("got Passed=%v %q", res.Passed, res.Message)
+		}
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		t.Setenv("NETLIFY_AUTH_TOKEN", "")
+		root := writeFiles(t, map[string]string{"netlify.toml": "[build]\n", ".env.example": "A=\n"})
+		res, _ := PlatformEnvCheck{}.Run(Context{RootDir: root, Config: cfg})
+		if !res.Passed || !strings.Contains(res.Message, "NETLIFY_AUTH_TOKEN is not set") {
+			t.Errorf("got Passed=%v %q", res.Passed, res.Message)
+		}
+	})
+}
+
+func TestDetectHeroku(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		token string
+		want  bool
+	}{
+		{"Heroku app.json", map[string]string{"app.json": `{"formation": {"web": {"quantity": 1}}}`}, "", true},
+		{"Expo app.json", map[string]string{"app.json": `{"expo": {"name": "app"}}`}, "", false},
+		{"heroku.yml", map[string]string{"heroku.yml": "build: {}\n"}, "", true},
+		{"Procfile alone", map[string]string{"Procfile": "web: npm start\n"}, "", false},
+		{"Procfile with Heroku credentials", map[string]string{"Procfile": "web: npm start\n"}, "key", true},
+		{"Procfile on Render", map[string]string{"Procfile": "web: npm start\n", "render.yaml": "services: []\n"}, "key", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("HEROKU_APP", "")
+			t.Setenv("HEROKU_API_KEY", tc.token)
+			if got := detectHeroku(writeFiles(t, tc.files)); got != tc.want {
+				t.Errorf("detectHeroku = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
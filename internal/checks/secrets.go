@@ -144,7 +144,7 @@ func (c SecretScanCheck) Run(ctx Context) (CheckResult, error) {
 	filesScanned := 0
 	filesErrored := 0
 
-	err := filepath.Walk(ctx.RootDir, func(path string, info os.FileInfo, err error) error {
+	err := walk(ctx.RootDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			if info != nil && info.IsDir() {
 				filesErrored++
@@ -0,0 +1,298 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/preflightsh/preflight/internal/fsutil"
+	"gopkg.in/yaml.v3"
+)
+
+// secretsManagerRunFiles are where a manager's CLI wrapper (doppler run,
+// op run, dotenvx run) is typically invoked.
+var secretsManagerRunFiles = []string{
+	"package.json", "Procfile", "Makefile", "Dockerfile", "fly.toml", "docker-compose.yml", "docker-compose.yaml",
+}
+
+// reProductionName matches an environment/config name that denotes
+// production (Doppler's default is "prd").
+var reProductionName = regexp.MustCompile(`(?i)(^|[^a-z])(prd|prod|production)([^a-z]|$)`)
+
+// secretsManager describes how to recognise one secrets manager and,
+// where its config names environments, whether production is covered.
+type secretsManager struct {
+	name string
+	// files are project-relative config files whose presence means the
+	// manager is in use.
+	files []string
+	// patterns match code, manifests or CLI invocations.
+	patterns []*regexp.Regexp
+	// production reports whether the config references a production
+	// environment. known is false when the config doesn't say either way.
+	production func(rootDir string) (ok, known bool, detail string)
+}
+
+var secretsManagers = []secretsManager{
+	{
+		name:  "Doppler",
+		files: []string{"doppler.yaml", ".doppler.yaml"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bdoppler\s+run\b`),
+			regexp.MustCompile(`@dopplerhq/`),
+		},
+		production: dopplerProduction,
+	},
+	{
+		name:  "HashiCorp Vault",
+		files: []string{"vault-agent.hcl", "vault.hcl", ".vault.hcl", "vault-agent.json"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`node-vault|hashi-vault-js`),
+			regexp.MustCompile(`\bhvac\b`),
+			regexp.MustCompile(`github\.com/hashicorp/vault/api`),
+			regexp.MustCompile(`\bvault\s+(agent|kv\s+get)\b`),
+		},
+		production: vaultProduction,
+	},
+	{
+		name: "AWS Secrets Manager",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`@aws-sdk/client-secrets-manager`),
+			regexp.MustCompile(`aws-sdk-go(-v2)?/service/secretsmanager`),
+			regexp.MustCompile(`aws-secretsmanager-caching`),
+			regexp.MustCompile(`client\(\s*['"]secretsmanager['"]`),
+			regexp.MustCompile(`Aws::SecretsManager`),
+		},
+	},
+	{
+		name: "1Password CLI",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bop\s+(run|inject)\b`),
+			regexp.MustCompile(`op://`),
+		},
+		production: onePasswordProduction,
+	},
+	{
+		name:  "dotenvx",
+		files: []string{".env.keys"},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`@dotenvx/dotenvx`),
+			regexp.MustCompile(`\bdotenvx\s+run\b`),
+			regexp.MustCompile(`DOTENV_PUBLIC_KEY`),
+		},
+		production: dotenvxProduction,
+	},
+}
+
+// detectSecretsManagers returns the names of the secrets managers the
+// project uses, in secretsManagers order.
+func detectSecretsManagers(rootDir, stack string) []string {
+	var found []string
+	for _, m := range secretsManagers {
+		if secretsManagerUsed(rootDir, stack, m) {
+			found = append(found, m.name)
+		}
+	}
+	return found
+}
+
+func secretsManagerUsed(rootDir, stack string, m secretsManager) bool {
+	for _, f := range m.files {
+		if fsutil.FileExists(rootDir, f) {
+			return true
+		}
+	}
+	if len(m.patterns) == 0 {
+		return false
+	}
+	files := append([]string{}, secretsManagerRunFiles...)
+	files = append(files, envTemplateFiles(rootDir)...)
+	for _, f := range files {
+		content, err := os.ReadFile(filepath.Join(rootDir, f))
+		if err != nil {
+			continue
+		}
+		for _, p := range m.patterns {
+			if p.Match(content) {
+				return true
+			}
+		}
+	}
+	return searchForPatterns(rootDir, stack, m.patterns)
+}
+
+// envTemplateFiles returns the project-root .env* files (.env.example,
+// .env.production, .env.tpl, ...).
+func envTemplateFiles(rootDir string) []string {
+	matches, _ := filepath.Glob(filepath.Join(rootDir, ".env*"))
+	var out []string
+	for _, m := range matches {
+		out = append(out, filepath.Base(m))
+	}
+	return out
+}
+
+type dopplerSetup struct {
+	Project string `yaml:"project"`
+	Config  string `yaml:"config"`
+}
+
+// dopplerProduction checks that doppler.yaml's setup (a single mapping or a
+// list of them, for monorepos) selects a production config.
+func dopplerProduction(rootDir string) (bool, bool, string) {
+	for _, name := range []string{"doppler.yaml", ".doppler.yaml"} {
+		data, err := os.ReadFile(filepath.Join(rootDir, name))
+		if err != nil {
+			continue
+		}
+		var doc struct {
+			Setup yaml.Node `yaml:"setup"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return false, false, ""
+		}
+		var setups []dopplerSetup
+		if doc.Setup.Kind == yaml.SequenceNode {
+			_ = doc.Setup.Decode(&setups)
+		} else {
+			var one dopplerSetup
+			_ = doc.Setup.Decode(&one)
+			setups = append(setups, one)
+		}
+
+		var configs []string
+		for _, s := range setups {
+			if s.Config == "" {
+				continue
+			}
+			if reProductionName.MatchString(s.Config) {
+				return true, true, name + " selects config " + s.Config
+			}
+			configs = append(configs, s.Config)
+		}
+		if len(configs) == 0 {
+			return false, false, ""
+		}
+		return false, true, name + " only selects config " + strings.Join(configs, ", ")
+	}
+	return false, false, ""
+}
+
+// vaultProduction looks for a production path or namespace in the Vault
+// agent config.
+func vaultProduction(rootDir string) (bool, bool, string) {
+	for _, name := range []string{"vault-agent.hcl", "vault.hcl", ".vault.hcl", "vault-agent.json"} {
+		data, err := os.ReadFile(filepath.Join(rootDir, name))
+		if err != nil {
+			continue
+		}
+		if reProductionName.Match(data) {
+			return true, true, name + " references a production path"
+		}
+		return false, true, name + " doesn't reference a production path or namespace"
+	}
+	return false, false, ""
+}
+
+var reOnePasswordRef = regexp.MustCompile(`op://([^/\s"']+)/([^/\s"']+)`)
+
+// onePasswordProduction checks the op:// references in the .env files for
+// a production vault or item. A project using one vault for every
+// environment has no such reference, so absence is treated as unknown.
+func onePasswordProduction(rootDir string) (bool, bool, string) {
+	for _, f := range envTemplateFiles(rootDir) {
+		data, err := os.ReadFile(filepath.Join(rootDir, f))
+		if err != nil {
+			continue
+		}
+		for _, m := range reOnePasswordRef.FindAllSubmatch(data, -1) {
+			if reProductionName.Match(m[1]) || reProductionName.Match(m[2]) {
+				return true, true, f + " references op://" + string(m[1]) + "/" + string(m[2])
+			}
+		}
+	}
+	return false, false, ""
+}
+
+// dotenvxProduction checks for an encrypted .env.production.
+func dotenvxProduction(rootDir string) (bool, bool, string) {
+	data, err := os.ReadFile(filepath.Join(rootDir, ".env.production"))
+	if err != nil {
+		return false, true, "no .env.production found"
+	}
+	if !strings.Contains(string(data), "DOTENV_PUBLIC_KEY") {
+		return false, true, ".env.production isn't encrypted (run `dotenvx encrypt -f .env.production`)"
+	}
+	return true, true, ".env.production is encrypted"
+}
+
+// SecretsManagerCheck detects secret managers (Doppler, Vault, AWS Secrets
+// Manager, 1Password CLI, dotenvx) and verifies their config covers the
+// production environment.
+type SecretsManagerCheck struct{}
+
+func (c SecretsManagerCheck) ID() string {
+	return "secrets_manager"
+}
+
+func (c SecretsManagerCheck) Title() string {
+	return "Secrets manager"
+}
+
+func (c SecretsManagerCheck) Run(ctx Context) (CheckResult, error) {
+	var used []string
+	var problems []string
+	var details []string
+
+	for _, m := range secretsManagers {
+		if !secretsManagerUsed(ctx.RootDir, ctx.Config.Stack, m) {
+			continue
+		}
+		used = append(used, m.name)
+		if m.production == nil {
+			continue
+		}
+		ok, known, detail := m.production(ctx.RootDir)
+		if detail != "" {
+			details = append(details, m.name+": "+detail)
+		}
+		if known && !ok {
+			problems = append(problems, m.name+": "+detail)
+		}
+	}
+
+	if len(used) == 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityInfo,
+			Passed:   true,
+			Message:  "No secrets manager detected, skipping",
+		}, nil
+	}
+
+	if len(problems) > 0 {
+		return CheckResult{
+			ID:       c.ID(),
+			Title:    c.Title(),
+			Severity: SeverityWarn,
+			Passed:   false,
+			Message:  "Secrets manager config doesn't cover production: " + strings.Join(problems, "; "),
+			Suggestions: []string{
+				"Point the production deploy at the manager's production environment/config (e.g. Doppler's prd config)",
+				"Keep per-environment configs so development secrets never reach production",
+			},
+			Details: details,
+		}, nil
+	}
+
+	return CheckResult{
+		ID:       c.ID(),
+		Title:    c.Title(),
+		Severity: SeverityInfo,
+		Passed:   true,
+		Message:  "Secrets managed by " + strings.Join(used, ", "),
+		Details:  details,
+	}, nil
+}
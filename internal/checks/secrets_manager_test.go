@@ -0,0 +1,57 @@
+package checks_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/preflightsh/preflight/internal/checks"
+	"github.com/preflightsh/preflight/internal/checks/checktest"
+	"github.com/preflightsh/preflight/internal/config"
+)
+
+func TestSecretsManagerCheck(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  bool
+		msg   string
+	}{
+		{"none", map[string]string{"index.js": "process.env.X"}, true, "skipping"},
+		{"doppler prd", map[string]string{"doppler.yaml": "setup:\n  project: web\n  config: prd\n"}, true, "Doppler"},
+		{"doppler dev only", map[string]string{"doppler.yaml": "setup:\n  - project: web\n    config: dev\n  - project: api\n    config: dev_personal\n"}, false, "dev, dev_personal"},
+		{"doppler run without config", map[string]string{"package.json": `{"scripts":{"start":"doppler run -- node server.js"}}`}, true, "Doppler"},
+		{"aws sdk", map[string]string{"package.json": `{"dependencies":{"@aws-sdk/client-secrets-manager":"^3"}}`}, true, "AWS Secrets Manager"},
+		{"1password refs", map[string]string{".env.tpl": "DB=op://Production/db/url\n"}, true, "1Password"},
+		{"dotenvx without production", map[string]string{".env": "#/ DOTENV_PUBLIC_KEY=\"03ab\"\nA=encrypted:xyz\n"}, false, "no .env.production"},
+		{"vault without prod path", map[string]string{"vault-agent.hcl": `template { source = "secret/data/staging/app" }`}, false, "production path"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := checktest.NewProject(t, tc.files).Run(checks.SecretsManagerCheck{})
+			if res.Passed != tc.want || !strings.Contains(res.Message, tc.msg) {
+				t.Errorf("got Passed=%v %q, want Passed=%v containing %q", res.Passed, res.Message, tc.want, tc.msg)
+			}
+		})
+	}
+}
+
+// With a secrets manager a missing local .env is expected, but a .env that
+// has drifted from .env.example is still reported.
+func TestEnvParityCheck_SecretsManager(t *testing.T) {
+	files := map[string]string{
+		".env.example": "DATABASE_URL=\nSTRIPE_KEY=\n",
+		"doppler.yaml": "setup:\n  project: web\n  config: prd\n",
+	}
+	p := checktest.NewProject(t, files)
+	p.Config.Checks.EnvParity = &config.EnvParityConfig{Enabled: true, EnvFile: ".env", ExampleFile: ".env.example"}
+	res := p.Run(checks.EnvParityCheck{})
+	if !res.Passed || !strings.Contains(res.Message, "supplied by Doppler") {
+		t.Errorf("without .env: got Passed=%v %q, want pass mentioning Doppler", res.Passed, res.Message)
+	}
+
+	p.WriteFile(".env", "DATABASE_URL=postgres://localhost\nLEGACY_TOKEN=x\n")
+	res = p.Run(checks.EnvParityCheck{})
+	if res.Passed || !strings.Contains(res.Message, "LEGACY_TOKEN") {
+		t.Errorf("with drifted .env: got Passed=%v %q, want the drift reported", res.Passed, res.Message)
+	}
+}
@@ -130,7 +130,7 @@ func (c SentryCheck) Run(ctx Context) (CheckResult, error) {
 			continue
 		}
 
-		err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		err := walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return nil
 			}
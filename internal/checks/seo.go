@@ -63,7 +63,7 @@ func (c SEOMetadataCheck) Run(ctx Context) (CheckResult, error) {
 		generateMetadataPattern := regexp.MustCompile(`(?s)export\s+(async\s+)?function\s+generateMetadata`)
 		metadataExportPattern := regexp.MustCompile(`(?s)export\s+(const|let|var)\s+metadata\s*[=:]`)
 
-		_ = filepath.Walk(appDir, func(path string, info os.FileInfo, err error) error {
+		_ = walk(appDir, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				if info != nil && info.IsDir() {
 					return filepath.SkipDir
@@ -108,20 +108,23 @@ func (c SEOMetadataCheck) Run(ctx Context) (CheckResult, error) {
 		}
 	}
 
-	// Required SEO elements
-	checks := map[string]*regexp.Regexp{
-		"title":          regexp.MustCompile(`<title[^>]*>`),
-		"description":    regexp.MustCompile(`<meta[^>]+name=["']description["'][^>]*>`),
-		"og:title":       regexp.MustCompile(`<meta[^>]+property=["']og:title["'][^>]*>`),
-		"og:description": regexp.MustCompile(`<meta[^>]+property=["']og:description["'][^>]*>`),
+	// Required SEO elements, in the order they're reported
+	checks := []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		{"title", regexp.MustCompile(`<title[^>]*>`)},
+		{"description", regexp.MustCompile(`<meta[^>]+name=["']description["'][^>]*>`)},
+		{"og:title", regexp.MustCompile(`<meta[^>]+property=["']og:title["'][^>]*>`)},
+		{"og:description", regexp.MustCompile(`<meta[^>]+property=["']og:description["'][^>]*>`)},
 	}
 
 	var missing []string
-	for name, pattern := range checks {
-		if !pattern.MatchString(contentStr) {
+	for _, check := range checks {
+		if !check.pattern.MatchString(contentStr) {
 			// Check for alternate patterns (some frameworks use different formats)
-			if !checkAlternatePatterns(contentStr, name) {
-				missing = append(missing, name)
+			if !checkAlternatePatterns(contentStr, check.name) {
+				missing = append(missing, check.name)
 			}
 		}
 	}
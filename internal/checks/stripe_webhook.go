@@ -78,7 +78,7 @@ func (c StripeWebhookCheck) Run(ctx Context) (CheckResult, error) {
 			continue
 		}
 
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || info.IsDir() || initFound {
 				return nil
 			}
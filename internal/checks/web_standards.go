@@ -180,7 +180,7 @@ func detectIndexNowInSource(ctx Context, key string) (string, bool) {
 		if _, err := os.Stat(dirPath); err != nil {
 			continue
 		}
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || found != "" {
 				return nil
 			}
@@ -334,7 +334,7 @@ func (c RobotsTxtCheck) Run(ctx Context) (CheckResult, error) {
 		if _, err := os.Stat(dirPath); err != nil {
 			continue
 		}
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || robotsFound {
 				return nil
 			}
@@ -526,7 +526,7 @@ func (c SitemapCheck) Run(ctx Context) (CheckResult, error) {
 		if _, err := os.Stat(dirPath); err != nil {
 			continue
 		}
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || sitemapFound {
 				return nil
 			}
@@ -1101,7 +1101,7 @@ func (c LLMsTxtCheck) Run(ctx Context) (CheckResult, error) {
 		if _, err := os.Stat(dirPath); err != nil {
 			continue
 		}
-		_ = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		_ = walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil || llmsFound {
 				return nil
 			}
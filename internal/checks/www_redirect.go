@@ -77,8 +77,8 @@ func (c WWWRedirectCheck) Run(ctx Context) (CheckResult, error) {
 	nonWwwURL := scheme + "://" + nonWwwHost
 
 	// Check both URLs
-	wwwFinal, wwwErr := getFinalURL(ctx.reqContext(), wwwURL)
-	nonWwwFinal, nonWwwErr := getFinalURL(ctx.reqContext(), nonWwwURL)
+	wwwFinal, wwwErr := getFinalURL(ctx.reqContext(), ctx.UserAgent, wwwURL)
+	nonWwwFinal, nonWwwErr := getFinalURL(ctx.reqContext(), ctx.UserAgent, nonWwwURL)
 
 	// Both fail to resolve
 	if wwwErr != nil && nonWwwErr != nil {
@@ -171,17 +171,16 @@ func (c WWWRedirectCheck) Run(ctx Context) (CheckResult, error) {
 	}, nil
 }
 
-func getFinalURL(ctx context.Context, urlStr string) (string, error) {
+func getFinalURL(ctx context.Context, userAgent, urlStr string) (string, error) {
 	// This call starts with a user-configured URL and follows redirects;
 	// SafeHTTPClient guards both the initial dial AND each redirect hop
 	// against private / loopback / link-local addresses.
-	client := netutil.SafeHTTPClient(5 * time.Second)
+	client := netutil.Polite(netutil.SafeHTTPClient(5*time.Second), userAgent)
 
 	req, err := http.NewRequestWithContext(ctx, "HEAD", urlStr, nil)
 	if err != nil {
 		return "", fmt.Errorf("build request for %s: %w", urlStr, err)
 	}
-	req.Header.Set("User-Agent", "Preflight/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -2,99 +2,268 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type PreflightConfig struct {
-	ProjectName string                   `yaml:"projectName"`
-	Stack       string                   `yaml:"stack"`
+	ProjectName string                   `yaml:"projectName" doc:"Project name shown in reports and the dashboard"`
+	Stack       string                   `yaml:"stack" default:"unknown" doc:"Framework or CMS (rails, next, laravel, craft, static, ...); picks where checks look for templates and web roots"`
 	URLs        URLConfig                `yaml:"urls,omitempty"`
 	Services    map[string]ServiceConfig `yaml:"services,omitempty"`
 	Checks      ChecksConfig             `yaml:"checks,omitempty"`
-	Ignore      []string                 `yaml:"ignore,omitempty"`
+	Compliance  ComplianceConfig         `yaml:"compliance,omitempty"`
+	DNS         DNSConfig                `yaml:"dns,omitempty"`
+	Alerts      AlertsConfig             `yaml:"alerts,omitempty"`
+	Limits      LimitsConfig             `yaml:"limits,omitempty"`
+	HTTP        HTTPConfig               `yaml:"http,omitempty"`
+	Ignore      []string                 `yaml:"ignore,omitempty" doc:"Check and service IDs to skip (list them with preflight checks)"`
 }
 
 type URLConfig struct {
-	Staging    string `yaml:"staging,omitempty"`
-	Production string `yaml:"production,omitempty"`
+	Staging    string `yaml:"staging,omitempty" doc:"Staging base URL; live-site checks report it alongside production"`
+	Production string `yaml:"production,omitempty" doc:"Production base URL; enables the live-site checks (SSL, headers, redirects, DNS)"`
+}
+
+// ComplianceConfig selects the legal regimes the site must satisfy. Each
+// region toggles its own set of legal checks (see checks.ComplianceChecks).
+type ComplianceConfig struct {
+	Regions []string `yaml:"regions,omitempty" checks:"consent_banner,consent_mode,do_not_sell,impressum" doc:"Legal regimes the site serves: eu, uk, us-ca, dach"`
+	// Vertical opts regulated industries into age-gate and responsible-use
+	// checks. Empty means the site isn't in a regulated vertical.
+	Vertical string `yaml:"vertical,omitempty" checks:"age_gate" doc:"Regulated industry: alcohol, gambling or vaping"`
+}
+
+// Compliance regions accepted in compliance.regions.
+const (
+	RegionEU   = "eu"    // GDPR / ePrivacy: cookie consent, Consent Mode
+	RegionUK   = "uk"    // UK GDPR / PECR: same checks as eu
+	RegionUSCA = "us-ca" // CCPA/CPRA: "Do Not Sell or Share" link
+	RegionDACH = "dach"  // Germany/Austria/Switzerland: Impressum
+)
+
+// ComplianceRegions lists every valid compliance region, in display order.
+var ComplianceRegions = []string{RegionEU, RegionUK, RegionUSCA, RegionDACH}
+
+// Regulated verticals accepted in compliance.vertical.
+const (
+	VerticalAlcohol  = "alcohol"
+	VerticalGambling = "gambling"
+	VerticalVaping   = "vaping"
+)
+
+// ComplianceVerticals lists every valid compliance vertical.
+var ComplianceVerticals = []string{VerticalAlcohol, VerticalGambling, VerticalVaping}
+
+// NormalizeRegion canonicalizes a compliance region as written by a user.
+// Region IDs are case-insensitive; "de", "at" and "ch" are accepted as
+// shorthands for the DACH profile. Unknown regions are returned lowercased
+// for the caller to reject.
+func NormalizeRegion(region string) string {
+	region = strings.ToLower(strings.TrimSpace(region))
+	switch region {
+	case "de", "at", "ch":
+		return RegionDACH
+	}
+	return region
+}
+
+// HasRegion reports whether region is listed in compliance.regions.
+func (c ComplianceConfig) HasRegion(region string) bool {
+	for _, r := range c.Regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// DNSConfig picks the resolver DNS-based checks (email auth, IPv6) query.
+type DNSConfig struct {
+	// Resolver is "system" (the default), "cloudflare", "google", or the
+	// https:// URL of a DNS-over-HTTPS (RFC 8484) endpoint. DoH avoids CI
+	// runners that block raw DNS and corporate resolvers that hide public
+	// records.
+	Resolver string `yaml:"resolver,omitempty" default:"system" checks:"email_auth,ipv6" doc:"DNS resolver: system, cloudflare, google, or an https:// DNS-over-HTTPS URL"`
+}
+
+// DNSResolvers lists the named dns.resolver values; an https:// URL is
+// also accepted.
+var DNSResolvers = []string{"system", "cloudflare", "google"}
+
+// AlertsConfig sets up change alerts for scheduled scans (cron, CI
+// schedules): the webhook is called when a check changes state or flaps,
+// not with the full summary on every run. The webhook can also come from
+// PREFLIGHT_ALERT_WEBHOOK, so a secret URL doesn't have to be committed.
+type AlertsConfig struct {
+	Webhook         string `yaml:"webhook,omitempty" secret:"true" doc:"URL to POST a JSON alert to when a check changes state (Slack-compatible text field); empty disables alerts unless PREFLIGHT_ALERT_WEBHOOK is set"`
+	CooldownMinutes int    `yaml:"cooldownMinutes,omitempty" default:"60" doc:"Minimum minutes between two alerts for the same check; changes inside the cooldown are reported when it ends. The cooldown can't be turned off: 0 or less uses the default, so the minimum is 1"`
+	FlapThreshold   int    `yaml:"flapThreshold,omitempty" default:"3" doc:"State changes within flapWindowHours that mark a check as flapping"`
+	FlapWindowHours int    `yaml:"flapWindowHours,omitempty" default:"24" doc:"Window in hours for counting state changes towards flapThreshold"`
+}
+
+// LimitsConfig caps what a scan may use, to protect shared CI runners from
+// pathological repos. When a limit is hit the scan still reports what it
+// has, marked incomplete. Zero means no limit.
+type LimitsConfig struct {
+	TimeoutSeconds int `yaml:"timeoutSeconds,omitempty" default:"none" doc:"Scan deadline in seconds; checks that haven't finished by then are listed as not run and the report is marked incomplete"`
+	MaxFiles       int `yaml:"maxFiles,omitempty" default:"none" doc:"Total files the file-scanning checks may visit across the scan; once reached, file searches stop and the report is marked incomplete"`
+	MaxConcurrency int `yaml:"maxConcurrency,omitempty" default:"none" doc:"Most goroutines a scan may fan out to at once (homepage fetches, multi-region probes)"`
+}
+
+// HTTPConfig controls how live checks present themselves to the sites
+// they request.
+type HTTPConfig struct {
+	// Identify sends a "preflight/<version>" User-Agent so site owners can
+	// recognize (and allowlist) scan traffic. Nil means true.
+	Identify *bool `yaml:"identify,omitempty" default:"true" doc:"Send a preflight/<version> User-Agent on live checks; false sends Go's default User-Agent instead"`
+}
+
+// IdentifyEnabled reports whether live checks send the preflight
+// User-Agent.
+func (h HTTPConfig) IdentifyEnabled() bool {
+	return h.Identify == nil || *h.Identify
 }
 
 type ServiceConfig struct {
-	Declared bool `yaml:"declared"`
+	Declared bool `yaml:"declared" default:"false" doc:"Whether the project uses the service; only declared services get their integration check"`
 }
 
 type ChecksConfig struct {
-	EnvParity      *EnvParityConfig      `yaml:"envParity,omitempty"`
-	HealthEndpoint *HealthEndpointConfig `yaml:"healthEndpoint,omitempty"`
-	StripeWebhook  *StripeWebhookConfig  `yaml:"stripeWebhook,omitempty"`
-	SEOMeta        *SEOMetaConfig        `yaml:"seoMeta,omitempty"`
-	Security       *SecurityConfig       `yaml:"security,omitempty"`
-	Secrets        *SecretsConfig        `yaml:"secrets,omitempty"`
-	AdsTxt         *AdsTxtConfig         `yaml:"adsTxt,omitempty"`
-	License        *LicenseConfig        `yaml:"license,omitempty"`
-	IndexNow       *IndexNowConfig       `yaml:"indexNow,omitempty"`
-	EmailAuth      *EmailAuthConfig      `yaml:"emailAuth,omitempty"`
-	HumansTxt      *HumansTxtConfig      `yaml:"humansTxt,omitempty"`
+	EnvParity         *EnvParityConfig         `yaml:"envParity,omitempty" checks:"envParity,platform_env"`
+	HealthEndpoint    *HealthEndpointConfig    `yaml:"healthEndpoint,omitempty" checks:"healthEndpoint"`
+	StripeWebhook     *StripeWebhookConfig     `yaml:"stripeWebhook,omitempty" checks:"stripe"`
+	SEOMeta           *SEOMetaConfig           `yaml:"seoMeta,omitempty" checks:"seoMeta,canonical,ogTwitter,viewport,lang,structured_data,favicon,legal_pages"`
+	Security          *SecurityConfig          `yaml:"security,omitempty" checks:"securityHeaders"`
+	Secrets           *SecretsConfig           `yaml:"secrets,omitempty" checks:"secrets"`
+	AdsTxt            *AdsTxtConfig            `yaml:"adsTxt,omitempty" checks:"adsTxt"`
+	License           *LicenseConfig           `yaml:"license,omitempty" checks:"license"`
+	IndexNow          *IndexNowConfig          `yaml:"indexNow,omitempty" checks:"indexNow"`
+	EmailAuth         *EmailAuthConfig         `yaml:"emailAuth,omitempty" checks:"email_auth"`
+	IPv6              *IPv6Config              `yaml:"ipv6,omitempty" checks:"ipv6"`
+	MultiRegion       *MultiRegionConfig       `yaml:"multiRegion,omitempty" checks:"multi_region"`
+	HumansTxt         *HumansTxtConfig         `yaml:"humansTxt,omitempty" checks:"humansTxt"`
+	ConsentMode       *ConsentModeConfig       `yaml:"consentMode,omitempty" checks:"consent_mode"`
+	LegalPlaceholders *LegalPlaceholdersConfig `yaml:"legalPlaceholders,omitempty" checks:"legal_placeholders"`
+	LegalFreshness    *LegalFreshnessConfig    `yaml:"legalFreshness,omitempty" checks:"legal_freshness"`
+	EmailObfuscation  *EmailObfuscationConfig  `yaml:"emailObfuscation,omitempty" checks:"email_obfuscation"`
+	DeadCode          *DeadCodeConfig          `yaml:"deadCode,omitempty" checks:"dead_code"`
+	BuildFreshness    *BuildFreshnessConfig    `yaml:"buildFreshness,omitempty" checks:"build_freshness"`
 }
 
 type EnvParityConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	EnvFile     string `yaml:"envFile"`
-	ExampleFile string `yaml:"exampleFile"`
+	Enabled     bool   `yaml:"enabled" default:"false" doc:"Compare the env file with the example file"`
+	EnvFile     string `yaml:"envFile" default:".env" doc:"Local env file"`
+	ExampleFile string `yaml:"exampleFile" default:".env.example" doc:"Committed example listing every required variable"`
+	// Platform is the hosting platform whose production variables are
+	// compared with ExampleFile: vercel, netlify, fly, heroku, or none.
+	// Empty auto-detects from the platform's config files.
+	Platform string `yaml:"platform,omitempty" default:"auto-detect" doc:"Hosting platform to compare production variables with: vercel, netlify, fly, heroku or none"`
 }
 
+// EnvPlatforms are the accepted checks.envParity.platform values.
+var EnvPlatforms = []string{"vercel", "netlify", "fly", "heroku", "none"}
+
 type HealthEndpointConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Path    string `yaml:"path"`
+	Enabled bool   `yaml:"enabled" default:"on when urls.production or urls.staging is set" doc:"Check that the health endpoint responds"`
+	Path    string `yaml:"path" default:"/health" doc:"Health endpoint path"`
 }
 
 type StripeWebhookConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	URL     string `yaml:"url"`
+	Enabled bool   `yaml:"enabled" default:"false" doc:"Check that the Stripe webhook endpoint is reachable"`
+	URL     string `yaml:"url" secret:"true" doc:"Full URL of the webhook endpoint"`
 }
 
 type SEOMetaConfig struct {
-	Enabled    bool   `yaml:"enabled"`
-	MainLayout string `yaml:"mainLayout"`
+	Enabled    bool   `yaml:"enabled" default:"on when the main layout is auto-detected" checks:"seoMeta,canonical,ogTwitter,viewport,lang" doc:"Check SEO metadata even when the main layout can't be auto-detected"`
+	MainLayout string `yaml:"mainLayout" default:"auto-detect" doc:"Template that renders <head>, relative to the project root"`
 }
 
 type SecurityConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" default:"false" doc:"Check HSTS, CSP and other security headers on production and staging"`
 }
 
 type SecretsConfig struct {
-	Enabled   bool                   `yaml:"enabled"`
+	Enabled   bool                   `yaml:"enabled" default:"false" doc:"Scan the project for leaked API keys and credentials"`
 	Allowlist []SecretAllowlistEntry `yaml:"allowlist,omitempty"`
 }
 
 type SecretAllowlistEntry struct {
-	Path        string `yaml:"path"`
-	Fingerprint string `yaml:"fingerprint,omitempty"`
-	Reason      string `yaml:"reason,omitempty"`
+	Path        string `yaml:"path" doc:"File path or doublestar glob the entry applies to"`
+	Fingerprint string `yaml:"fingerprint,omitempty" doc:"sha256:<hex> of one finding; empty allows every finding in path"`
+	Reason      string `yaml:"reason,omitempty" doc:"Why the finding is safe, for reviewers"`
 }
 
 type AdsTxtConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" default:"false" doc:"Check ads.txt, for ad-supported sites"`
 }
 
 type LicenseConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" default:"false" doc:"Check for a LICENSE file, for open source projects"`
 }
 
 type IndexNowConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Key     string `yaml:"key"`
+	Enabled bool   `yaml:"enabled" default:"false" doc:"Check the IndexNow key file is served"`
+	Key     string `yaml:"key" secret:"true" doc:"IndexNow key; the key file is <key>.txt in the web root"`
 }
 
 type EmailAuthConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" default:"false" doc:"Check SPF and DMARC records on the production domain"`
+}
+
+// IPv6Config enables the IPv6 reachability check (AAAA record plus a
+// request over IPv6, or a TCP connection from check-host.net when the
+// machine running the scan has no IPv6 route).
+type IPv6Config struct {
+	Enabled bool `yaml:"enabled" default:"false" doc:"Check the production host has an AAAA record and responds over IPv6"`
+}
+
+// MultiRegionConfig enables the multi-region reachability probe. Without
+// probes it uses check-host.net nodes on each continent.
+type MultiRegionConfig struct {
+	Enabled bool                `yaml:"enabled" default:"false" doc:"Request the production URL from several continents"`
+	Probes  []RegionProbeConfig `yaml:"probes,omitempty"`
+}
+
+// RegionProbeConfig is a user-run probe endpoint (e.g. a serverless
+// function deployed to one region). Preflight calls GET <url>?url=<target>
+// and expects {"status": <code>} or {"error": "<reason>"} back.
+type RegionProbeConfig struct {
+	Name string `yaml:"name" doc:"Region label used in the report"`
+	URL  string `yaml:"url" doc:"Probe endpoint, called as GET <url>?url=<production URL>"`
 }
 
 type HumansTxtConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" default:"false" doc:"Check for a humans.txt crediting the team"`
+}
+
+type ConsentModeConfig struct {
+	Enabled bool `yaml:"enabled" default:"on when compliance.regions includes eu or uk" doc:"Check Google Consent Mode v2 calls when Google tags are present"`
+}
+
+type LegalPlaceholdersConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" doc:"Flag unfinished generator boilerplate in the privacy policy and terms"`
+}
+
+type LegalFreshnessConfig struct {
+	Enabled    bool `yaml:"enabled" default:"false" doc:"Check the legal pages' \"last updated\" date"`
+	MaxAgeDays int  `yaml:"maxAgeDays,omitempty" default:"365" doc:"Age in days after which a policy is reported as stale"`
+}
+
+type EmailObfuscationConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" doc:"Flag plain-text and mailto: addresses spammers can harvest"`
+}
+
+type DeadCodeConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" doc:"Flag placeholder pages and *-old/*.bak leftovers"`
+}
+
+type BuildFreshnessConfig struct {
+	Enabled bool `yaml:"enabled" default:"false" doc:"Warn when build output is older than its sources"`
 }
 
 // Load reads and parses the preflight.yml config file
@@ -108,7 +277,12 @@ func Load(rootDir string) (*PreflightConfig, error) {
 		}
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
+	return Parse(data)
+}
 
+// Parse parses the contents of a preflight.yml, applying defaults and
+// validating it the same way Load does.
+func Parse(data []byte) (*PreflightConfig, error) {
 	var cfg PreflightConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse preflight.yml: %w", err)
@@ -117,9 +291,123 @@ func Load(rootDir string) (*PreflightConfig, error) {
 	// Apply defaults
 	applyDefaults(&cfg)
 
+	if err := validateRegions(cfg.Compliance.Regions); err != nil {
+		return nil, err
+	}
+	if err := validateVertical(cfg.Compliance.Vertical); err != nil {
+		return nil, err
+	}
+	if cfg.Checks.EnvParity != nil {
+		if err := validateEnvPlatform(cfg.Checks.EnvParity.Platform); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateDNSResolver(cfg.DNS.Resolver); err != nil {
+		return nil, err
+	}
+	if err := validateAlertWebhook(cfg.Alerts.Webhook); err != nil {
+		return nil, err
+	}
+	if err := validateLimits(cfg.Limits); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// validateRegions rejects unknown compliance regions so a typo like "eu-west"
+// doesn't silently disable the legal checks it was meant to turn on.
+func validateRegions(regions []string) error {
+	for _, r := range regions {
+		known := false
+		for _, valid := range ComplianceRegions {
+			if r == valid {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown compliance region %q in preflight.yml (valid: %s)", r, strings.Join(ComplianceRegions, ", "))
+		}
+	}
+	return nil
+}
+
+// validateVertical rejects an unknown compliance.vertical for the same reason
+// validateRegions rejects unknown regions.
+func validateVertical(vertical string) error {
+	if vertical == "" {
+		return nil
+	}
+	for _, valid := range ComplianceVerticals {
+		if vertical == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown compliance vertical %q in preflight.yml (valid: %s)", vertical, strings.Join(ComplianceVerticals, ", "))
+}
+
+// validateEnvPlatform rejects an unknown checks.envParity.platform, which
+// would otherwise silently skip the platform comparison.
+func validateEnvPlatform(platform string) error {
+	if platform == "" {
+		return nil
+	}
+	for _, valid := range EnvPlatforms {
+		if platform == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown envParity platform %q in preflight.yml (valid: %s)", platform, strings.Join(EnvPlatforms, ", "))
+}
+
+// validateDNSResolver rejects an unknown dns.resolver rather than quietly
+// falling back to the system resolver the user was trying to avoid.
+func validateDNSResolver(resolver string) error {
+	if resolver == "" {
+		return nil
+	}
+	for _, valid := range DNSResolvers {
+		if resolver == valid {
+			return nil
+		}
+	}
+	if u, err := url.Parse(resolver); err == nil && u.Scheme == "https" && u.Host != "" {
+		return nil
+	}
+	return fmt.Errorf("unknown dns resolver %q in preflight.yml (valid: %s, or an https:// DoH URL)", resolver, strings.Join(DNSResolvers, ", "))
+}
+
+// validateAlertWebhook rejects a webhook that isn't an http(s) URL, which
+// would otherwise only surface as a failed POST on the next state change.
+func validateAlertWebhook(webhook string) error {
+	if webhook == "" {
+		return nil
+	}
+	if u, err := url.Parse(webhook); err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" {
+		return nil
+	}
+	return fmt.Errorf("alerts.webhook in preflight.yml must be an http(s) URL, got %q", webhook)
+}
+
+// validateLimits rejects negative limits, which would otherwise read as
+// "no limit" and quietly drop the protection the user asked for.
+func validateLimits(l LimitsConfig) error {
+	for _, v := range []struct {
+		key   string
+		value int
+	}{
+		{"timeoutSeconds", l.TimeoutSeconds},
+		{"maxFiles", l.MaxFiles},
+		{"maxConcurrency", l.MaxConcurrency},
+	} {
+		if v.value < 0 {
+			return fmt.Errorf("limits.%s in preflight.yml can't be negative (use 0 or omit it for no limit)", v.key)
+		}
+	}
+	return nil
+}
+
 func applyDefaults(cfg *PreflightConfig) {
 	if cfg.Stack == "" {
 		cfg.Stack = "unknown"
@@ -132,6 +420,30 @@ func applyDefaults(cfg *PreflightConfig) {
 		if cfg.Checks.EnvParity.ExampleFile == "" {
 			cfg.Checks.EnvParity.ExampleFile = ".env.example"
 		}
+		cfg.Checks.EnvParity.Platform = strings.ToLower(strings.TrimSpace(cfg.Checks.EnvParity.Platform))
+	}
+
+	for i, r := range cfg.Compliance.Regions {
+		cfg.Compliance.Regions[i] = NormalizeRegion(r)
+	}
+
+	cfg.Compliance.Vertical = strings.ToLower(strings.TrimSpace(cfg.Compliance.Vertical))
+
+	// Resolver names are case-insensitive; DoH URLs are kept as written.
+	cfg.DNS.Resolver = strings.TrimSpace(cfg.DNS.Resolver)
+	if !strings.Contains(cfg.DNS.Resolver, "://") {
+		cfg.DNS.Resolver = strings.ToLower(cfg.DNS.Resolver)
+	}
+
+	cfg.Alerts.Webhook = strings.TrimSpace(cfg.Alerts.Webhook)
+	if cfg.Alerts.CooldownMinutes <= 0 {
+		cfg.Alerts.CooldownMinutes = 60
+	}
+	if cfg.Alerts.FlapThreshold <= 0 {
+		cfg.Alerts.FlapThreshold = 3
+	}
+	if cfg.Alerts.FlapWindowHours <= 0 {
+		cfg.Alerts.FlapWindowHours = 24
 	}
 
 	if cfg.Checks.HealthEndpoint != nil {
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadComplianceRegions(t *testing.T) {
+	t.Run("normalizes case and DACH country shorthands", func(t *testing.T) {
+		root := writeProject(t, map[string]string{
+			"preflight.yml": "projectName: x\ncompliance:\n  regions: [EU, de, us-ca]\n",
+		})
+		cfg, err := Load(root)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		want := []string{RegionEU, RegionDACH, RegionUSCA}
+		if !reflect.DeepEqual(cfg.Compliance.Regions, want) {
+			t.Errorf("regions = %v, want %v", cfg.Compliance.Regions, want)
+		}
+		if !cfg.Compliance.HasRegion(RegionDACH) {
+			t.Error("HasRegion(dach) = false, want true")
+		}
+	})
+
+	t.Run("rejects unknown regions", func(t *testing.T) {
+		root := writeProject(t, map[string]string{
+			"preflight.yml": "projectName: x\ncompliance:\n  regions: [eu-west]\n",
+		})
+		_, err := Load(root)
+		if err == nil || !strings.Contains(err.Error(), "eu-west") {
+			t.Fatalf("Load err = %v, want unknown region error", err)
+		}
+	})
+}
+
+func TestLoadEnvParityPlatform(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"preflight.yml": "projectName: x\nchecks:\n  envParity:\n    enabled: true\n    platform: Vercel\n",
+	})
+	cfg, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Checks.EnvParity.Platform != "vercel" {
+		t.Errorf("platform = %q, want vercel", cfg.Checks.EnvParity.Platform)
+	}
+
+	root = writeProject(t, map[string]string{
+		"preflight.yml": "projectName: x\nchecks:\n  envParity:\n    platform: render\n",
+	})
+	if _, err := Load(root); err == nil || !strings.Contains(err.Error(), "render") {
+		t.Fatalf("Load err = %v, want unknown platform error", err)
+	}
+}
+
+func TestParseDNSResolver(t *testing.T) {
+	for resolver, want := range map[string]string{
+		"Cloudflare":                        "cloudflare",
+		"system":                            "system",
+		"https://dns.example.net/dns-query": "https://dns.example.net/dns-query",
+	} {
+		cfg, err := Parse([]byte("projectName: x\ndns:\n  resolver: " + resolver + "\n"))
+		if err != nil {
+			t.Errorf("Parse(resolver: %s): %v", resolver, err)
+			continue
+		}
+		if cfg.DNS.Resolver != want {
+			t.Errorf("resolver = %q, want %q", cfg.DNS.Resolver, want)
+		}
+	}
+
+	for _, resolver := range []string{"quad9", "http://dns.example.net/dns-query"} {
+		if _, err := Parse([]byte("projectName: x\ndns:\n  resolver: " + resolver + "\n")); err == nil {
+			t.Errorf("Parse(resolver: %s) succeeded, want unknown resolver error", resolver)
+		}
+	}
+}
+
+func TestParseAlerts(t *testing.T) {
+	cfg, err := Parse([]byte("projectName: x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := cfg.Alerts; a.Webhook != "" || a.CooldownMinutes != 60 || a.FlapThreshold != 3 || a.FlapWindowHours != 24 {
+		t.Errorf("default alerts = %+v", a)
+	}
+
+	cfg, err = Parse([]byte("alerts:\n  webhook: https://hooks.example.com/T1\n  cooldownMinutes: 15\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Alerts.Webhook != "https://hooks.example.com/T1" || cfg.Alerts.CooldownMinutes != 15 {
+		t.Errorf("alerts = %+v", cfg.Alerts)
+	}
+
+	for _, webhook := range []string{"hooks.example.com/T1", "ftp://hooks.example.com"} {
+		if _, err := Parse([]byte("alerts:\n  webhook: " + webhook + "\n")); err == nil {
+			t.Errorf("Parse(webhook: %s) succeeded, want error", webhook)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	cfg, err := Parse([]byte("limits:\n  timeoutSeconds: 300\n  maxFiles: 50000\n  maxConcurrency: 4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := cfg.Limits; l.TimeoutSeconds != 300 || l.MaxFiles != 50000 || l.MaxConcurrency != 4 {
+		t.Errorf("limits = %+v", l)
+	}
+
+	if _, err := Parse([]byte("limits:\n  maxFiles: -1\n")); err == nil {
+		t.Error("Parse(maxFiles: -1) succeeded, want error")
+	}
+}
+
+func TestHTTPIdentify(t *testing.T) {
+	for yml, want := range map[string]bool{
+		"projectName: x\n":           true,
+		"http:\n  identify: true\n":  true,
+		"http:\n  identify: false\n": false,
+	} {
+		cfg, err := Parse([]byte(yml))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cfg.HTTP.IdentifyEnabled(); got != want {
+			t.Errorf("IdentifyEnabled() for %q = %v, want %v", yml, got, want)
+		}
+	}
+}
+
+// FuzzParse checks that a malformed or hostile preflight.yml (it's often
+// committed to the repo being scanned) is rejected with an error rather
+// than a panic, and that a config Parse accepts is fully defaulted.
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"projectName: x\nstack: next\n",
+		"checks:\n  envParity:\n  healthEndpoint:\n",
+		"checks:\n  envParity: {enabled: true, platform: HEROKU}\ncompliance:\n  regions: [DE]\n",
+		"services: {stripe: {declared: true}}\nignore: [sitemap]\n",
+		"checks: [1, 2]\n",
+		"a: &a [*a]\n",
+		"urls: {production: !!binary aGVsbG8=}\n",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		cfg, err := Parse(data)
+		if err != nil {
+			return
+		}
+		if cfg.Stack == "" {
+			t.Error("Parse left Stack empty")
+		}
+		if p := cfg.Checks.EnvParity; p != nil && (p.EnvFile == "" || p.ExampleFile == "") {
+			t.Errorf("envParity defaults not applied: %+v", p)
+		}
+	})
+}
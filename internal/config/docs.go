@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// KeyDoc documents one preflight.yml setting. It is built from the struct
+// tags on PreflightConfig: yaml for the key, doc for the description,
+// default for the value used when the key is unset, and checks for the
+// check IDs that read it (inherited from the enclosing section). Fields
+// tagged secret:"true" hold credentials and are redacted by Settings.
+type KeyDoc struct {
+	// Key is the dotted path, e.g. checks.envParity.envFile. List elements
+	// appear as [] and map entries as <name>.
+	Key         string
+	Type        string
+	Default     string
+	Checks      []string
+	Description string
+}
+
+// Reference lists every preflight.yml setting in declaration order.
+func Reference() []KeyDoc {
+	var docs []KeyDoc
+	walkDocs(reflect.TypeOf(PreflightConfig{}), "", nil, &docs)
+	return docs
+}
+
+func walkDocs(t reflect.Type, prefix string, checks []string, docs *[]KeyDoc) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		fieldChecks := checks
+		if c := f.Tag.Get("checks"); c != "" {
+			fieldChecks = strings.Split(c, ",")
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Struct:
+			walkDocs(ft, key, fieldChecks, docs)
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
+			walkDocs(ft.Elem(), key+"[]", fieldChecks, docs)
+		case ft.Kind() == reflect.Map && ft.Elem().Kind() == reflect.Struct:
+			walkDocs(ft.Elem(), key+".<name>", fieldChecks, docs)
+		default:
+			*docs = append(*docs, KeyDoc{
+				Key:         key,
+				Type:        docType(ft),
+				Default:     f.Tag.Get("default"),
+				Checks:      fieldChecks,
+				Description: f.Tag.Get("doc"),
+			})
+		}
+	}
+}
+
+func docType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int64:
+		return "int"
+	case reflect.String:
+		return "string"
+	case reflect.Slice:
+		return "list of " + docType(t.Elem())
+	}
+	return t.String()
+}
+
+// Setting is one value set in a loaded config, with the checks that read
+// it.
+type Setting struct {
+	// Key is the dotted path, e.g. checks.multiRegion.probes[0].url.
+	Key    string
+	Value  string
+	Checks []string
+}
+
+// Settings lists every non-empty value in cfg in declaration order, with
+// secret values replaced by "(redacted)".
+func Settings(cfg *PreflightConfig) []Setting {
+	var out []Setting
+	walkSettings(reflect.ValueOf(*cfg), "", nil, &out)
+	return out
+}
+
+func walkSettings(v reflect.Value, prefix string, checks []string, out *[]Setting) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		fieldChecks := checks
+		if c := f.Tag.Get("checks"); c != "" {
+			fieldChecks = strings.Split(c, ",")
+		}
+
+		fv := v.Field(i)
+		if fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && fv.Elem().Kind() == reflect.Struct {
+			fv = fv.Elem()
+		}
+		switch {
+		case fv.Kind() == reflect.Struct:
+			walkSettings(fv, key, fieldChecks, out)
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < fv.Len(); j++ {
+				walkSettings(fv.Index(j), fmt.Sprintf("%s[%d]", key, j), fieldChecks, out)
+			}
+		case fv.Kind() == reflect.Map && fv.Type().Elem().Kind() == reflect.Struct:
+			keys := fv.MapKeys()
+			sort.Slice(keys, func(a, b int) bool { return keys[a].String() < keys[b].String() })
+			for _, k := range keys {
+				walkSettings(fv.MapIndex(k), key+"."+k.String(), fieldChecks, out)
+			}
+		default:
+			value := settingValue(fv)
+			if f.Tag.Get("secret") == "true" {
+				value = "(redacted)"
+			}
+			*out = append(*out, Setting{Key: key, Value: value, Checks: fieldChecks})
+		}
+	}
+}
+
+func settingValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(v.Interface())
+}
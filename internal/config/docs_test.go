@@ -0,0 +1,84 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReference(t *testing.T) {
+	byKey := make(map[string]KeyDoc)
+	for _, d := range Reference() {
+		if d.Description == "" {
+			t.Errorf("%s has no doc tag", d.Key)
+		}
+		if _, dup := byKey[d.Key]; dup {
+			t.Errorf("%s listed twice", d.Key)
+		}
+		byKey[d.Key] = d
+	}
+
+	for key, want := range map[string]KeyDoc{
+		"checks.envParity.envFile":        {Type: "string", Default: ".env", Checks: []string{"envParity", "platform_env"}},
+		"checks.secrets.allowlist[].path": {Type: "string", Checks: []string{"secrets"}},
+		"services.<name>.declared":        {Type: "bool", Default: "false"},
+		"checks.seoMeta.enabled":          {Type: "bool", Default: "on when the main layout is auto-detected", Checks: []string{"seoMeta", "canonical", "ogTwitter", "viewport", "lang"}},
+		"ignore":                          {Type: "list of string"},
+		"dns.resolver":                    {Type: "string", Default: "system", Checks: []string{"email_auth", "ipv6"}},
+	} {
+		got, ok := byKey[key]
+		if !ok {
+			t.Errorf("%s missing from Reference", key)
+			continue
+		}
+		if got.Type != want.Type || got.Default != want.Default || !reflect.DeepEqual(got.Checks, want.Checks) {
+			t.Errorf("%s = %s, default %q, checks %v; want %s, %q, %v", key, got.Type, got.Default, got.Checks, want.Type, want.Default, want.Checks)
+		}
+	}
+}
+
+func TestSettings(t *testing.T) {
+	cfg, err := Parse([]byte(`projectName: shop
+checks:
+  envParity:
+    enabled: true
+  indexNow:
+    enabled: true
+    key: abc123
+  multiRegion:
+    enabled: true
+    probes:
+      - name: eu
+        url: https://probe.example.com
+services:
+  stripe:
+    declared: true
+ignore: [sitemap, llmsTxt]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]Setting)
+	for _, s := range Settings(cfg) {
+		got[s.Key] = s
+	}
+	for key, want := range map[string]string{
+		"projectName":                      "shop",
+		"checks.envParity.envFile":         ".env",
+		"checks.indexNow.key":              "(redacted)",
+		"checks.multiRegion.probes[0].url": "https://probe.example.com",
+		"services.stripe.declared":         "true",
+		"ignore":                           "sitemap, llmsTxt",
+		"alerts.cooldownMinutes":           "60",
+	} {
+		if got[key].Value != want {
+			t.Errorf("%s = %q, want %q", key, got[key].Value, want)
+		}
+	}
+	if s := got["checks.multiRegion.probes[0].name"]; !reflect.DeepEqual(s.Checks, []string{"multi_region"}) {
+		t.Errorf("probe name checks = %v, want [multi_region]", s.Checks)
+	}
+	if _, ok := got["checks.seoMeta.enabled"]; ok {
+		t.Error("unset section listed")
+	}
+}
@@ -0,0 +1,181 @@
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Resolver is the subset of *net.Resolver that DNS-based checks use, so a
+// scan can swap the system resolver for DNS-over-HTTPS.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// DoHEndpoints maps the resolver names accepted in preflight.yml to their
+// RFC 8484 endpoints. They're addressed by IP so that reaching the
+// resolver doesn't itself need a DNS lookup, which is the point on runners
+// that block raw DNS; both providers' certificates cover these IPs.
+var DoHEndpoints = map[string]string{
+	"cloudflare": "https://1.1.1.1/dns-query",
+	"google":     "https://8.8.8.8/dns-query",
+}
+
+// DoHEndpoint returns the DNS-over-HTTPS URL for a dns.resolver setting:
+// a known provider name or an https:// URL. It returns "" for the system
+// resolver ("" or "system").
+func DoHEndpoint(resolver string) string {
+	if u, ok := DoHEndpoints[resolver]; ok {
+		return u
+	}
+	if strings.HasPrefix(resolver, "https://") {
+		return resolver
+	}
+	return ""
+}
+
+// maxDNSMessage caps a DoH response body; DNS messages can't exceed 64 KiB.
+const maxDNSMessage = 64 * 1024
+
+// DoHResolver resolves names over DNS-over-HTTPS (RFC 8484), so results
+// reflect public DNS even where a corporate resolver rewrites or hides
+// records. Errors are *net.DNSError like the system resolver's, with
+// IsNotFound set for NXDOMAIN and for names without records of the type.
+type DoHResolver struct {
+	// URL is the endpoint, e.g. https://1.1.1.1/dns-query.
+	URL    string
+	Client *http.Client
+}
+
+// LookupTXT returns the TXT records for name. Like net.Resolver, the
+// strings of a single record are joined into one.
+func (r *DoHResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	answers, err := r.query(ctx, name, dnsmessage.TypeTXT)
+	if err != nil {
+		return nil, err
+	}
+	var records []string
+	for _, a := range answers {
+		if txt, ok := a.Body.(*dnsmessage.TXTResource); ok {
+			records = append(records, strings.Join(txt.TXT, ""))
+		}
+	}
+	return records, nil
+}
+
+// LookupIP returns the addresses of host. network is "ip4" (A records),
+// "ip6" (AAAA) or "ip" (both), as for net.Resolver.LookupIP.
+func (r *DoHResolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	var qtypes []dnsmessage.Type
+	switch network {
+	case "ip":
+		qtypes = []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA}
+	case "ip4":
+		qtypes = []dnsmessage.Type{dnsmessage.TypeA}
+	case "ip6":
+		qtypes = []dnsmessage.Type{dnsmessage.TypeAAAA}
+	default:
+		return nil, net.UnknownNetworkError(network)
+	}
+
+	var ips []net.IP
+	for _, qtype := range qtypes {
+		answers, err := r.query(ctx, host, qtype)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				continue
+			}
+			return nil, err
+		}
+		for _, a := range answers {
+			switch body := a.Body.(type) {
+			case *dnsmessage.AResource:
+				ips = append(ips, net.IP(body.A[:]))
+			case *dnsmessage.AAAAResource:
+				ips = append(ips, net.IP(body.AAAA[:]))
+			}
+		}
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, Server: r.URL, IsNotFound: true}
+	}
+	return ips, nil
+}
+
+// query sends one question and returns the answers of type qtype.
+func (r *DoHResolver) query(ctx context.Context, name string, qtype dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	fqdn := name
+	if !strings.HasSuffix(fqdn, ".") {
+		fqdn += "."
+	}
+	qname, err := dnsmessage.NewName(fqdn)
+	if err != nil {
+		return nil, &net.DNSError{Err: "invalid name", Name: name}
+	}
+	// ID 0 keeps the request cacheable (RFC 8484 §4.1).
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack DNS query for %s: %w", name, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: name, Server: r.URL, IsTemporary: true}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &net.DNSError{Err: fmt.Sprintf("DoH server returned HTTP %d", resp.StatusCode), Name: name, Server: r.URL, IsTemporary: true}
+	}
+	body, err := io.ReadAll(LimitBody(resp.Body, maxDNSMessage))
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: name, Server: r.URL, IsTemporary: true}
+	}
+
+	var reply dnsmessage.Message
+	if err := reply.Unpack(body); err != nil {
+		return nil, &net.DNSError{Err: "cannot unmarshal DNS message", Name: name, Server: r.URL}
+	}
+	switch reply.Header.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, &net.DNSError{Err: "no such host", Name: name, Server: r.URL, IsNotFound: true}
+	default:
+		return nil, &net.DNSError{Err: "server misbehaving: " + reply.Header.RCode.String(), Name: name, Server: r.URL, IsTemporary: true}
+	}
+
+	// Answers can lead with the CNAME chain; keep only the asked-for type.
+	var answers []dnsmessage.Resource
+	for _, a := range reply.Answers {
+		if a.Header.Type == qtype {
+			answers = append(answers, a)
+		}
+	}
+	if len(answers) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: name, Server: r.URL, IsNotFound: true}
+	}
+	return answers, nil
+}
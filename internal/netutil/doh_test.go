@@ -0,0 +1,136 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeDoH answers TXT queries from records, and AAAA queries for
+// ipv6.example.com; names it doesn't know get NXDOMAIN.
+func fakeDoH(t *testing.T, records map[string][][]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var q dnsmessage.Message
+		if err := q.Unpack(body); err != nil || len(q.Questions) != 1 {
+			http.Error(w, "bad message", http.StatusBadRequest)
+			return
+		}
+		question := q.Questions[0]
+		reply := dnsmessage.Message{
+			Header:    dnsmessage.Header{Response: true, RecursionAvailable: true},
+			Questions: q.Questions,
+		}
+		txts, ok := records[question.Name.String()]
+		if !ok {
+			reply.Header.RCode = dnsmessage.RCodeNameError
+		}
+		if question.Type == dnsmessage.TypeAAAA && question.Name.String() == "ipv6.example.com." {
+			reply.Answers = append(reply.Answers, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeAAAA, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}},
+			})
+		}
+		if question.Type == dnsmessage.TypeTXT {
+			for _, txt := range txts {
+				reply.Answers = append(reply.Answers, dnsmessage.Resource{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET},
+					Body:   &dnsmessage.TXTResource{TXT: txt},
+				})
+			}
+		}
+		packed, err := reply.Pack()
+		if err != nil {
+			t.Errorf("pack reply: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/dns-message")
+		w.Write(packed)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDoHResolverLookupTXT(t *testing.T) {
+	srv := fakeDoH(t, map[string][][]string{
+		"example.com.":        {{"v=spf1 include:_spf.example.net ", "~all"}, {"google-site-verification=abc"}},
+		"_dmarc.example.com.": nil,
+	})
+	r := &DoHResolver{URL: srv.URL, Client: srv.Client()}
+	ctx := context.Background()
+
+	got, err := r.LookupTXT(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("LookupTXT: %v", err)
+	}
+	want := []string{"v=spf1 include:_spf.example.net ~all", "google-site-verification=abc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LookupTXT = %q, want %q", got, want)
+	}
+
+	for _, name := range []string{"_dmarc.example.com", "missing.example.com"} {
+		_, err := r.LookupTXT(ctx, name)
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			t.Errorf("LookupTXT(%s) error = %v, want a not-found *net.DNSError", name, err)
+		}
+	}
+}
+
+func TestDoHResolverLookupIP(t *testing.T) {
+	srv := fakeDoH(t, map[string][][]string{"ipv6.example.com.": nil, "ipv4only.example.com.": nil})
+	r := &DoHResolver{URL: srv.URL, Client: srv.Client()}
+	ctx := context.Background()
+
+	for _, network := range []string{"ip6", "ip"} {
+		ips, err := r.LookupIP(ctx, network, "ipv6.example.com")
+		if err != nil || len(ips) != 1 || ips[0].String() != "2001:db8::1" {
+			t.Errorf("LookupIP(%s) = %v, %v; want [2001:db8::1]", network, ips, err)
+		}
+	}
+
+	_, err := r.LookupIP(ctx, "ip6", "ipv4only.example.com")
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("LookupIP(no AAAA) error = %v, want a not-found *net.DNSError", err)
+	}
+}
+
+func TestDoHResolverServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := (&DoHResolver{URL: srv.URL, Client: srv.Client()}).LookupTXT(context.Background(), "example.com")
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) || dnsErr.IsNotFound || !dnsErr.IsTemporary {
+		t.Errorf("error = %v, want a temporary *net.DNSError", err)
+	}
+}
+
+func TestDoHEndpoint(t *testing.T) {
+	for resolver, want := range map[string]string{
+		"":                                  "",
+		"system":                            "",
+		"cloudflare":                        "https://1.1.1.1/dns-query",
+		"google":                            "https://8.8.8.8/dns-query",
+		"https://dns.example.net/dns-query": "https://dns.example.net/dns-query",
+	} {
+		if got := DoHEndpoint(resolver); got != want {
+			t.Errorf("DoHEndpoint(%q) = %q, want %q", resolver, got, want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package netutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxRetryAfter is the longest Retry-After a PoliteTransport will wait
+// out. A host asking for longer gets its 429 passed back to the check.
+const MaxRetryAfter = 30 * time.Second
+
+// PoliteTransport makes scan traffic identifiable and well-behaved: it
+// sets UserAgent on every request, and when a host answers 429 Too Many
+// Requests it waits out the Retry-After (retrying once) and holds back
+// later requests to that host until then.
+type PoliteTransport struct {
+	// Base is the transport requests are sent through; nil means
+	// http.DefaultTransport.
+	Base http.RoundTripper
+	// UserAgent replaces the request's User-Agent; empty leaves Go's
+	// default.
+	UserAgent string
+
+	mu         sync.Mutex
+	retryAfter map[string]time.Time // host -> earliest next request
+}
+
+// Polite returns a copy of c whose transport is wrapped in a
+// PoliteTransport. Redirect policy and timeout are kept.
+func Polite(c *http.Client, userAgent string) *http.Client {
+	polite := *c
+	polite.Transport = &PoliteTransport{Base: c.Transport, UserAgent: userAgent}
+	return &polite
+}
+
+func (t *PoliteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.UserAgent != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.UserAgent)
+	}
+
+	if err := t.waitForHost(req.Context(), req.URL.Host); err != nil {
+		return nil, err
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok || wait > MaxRetryAfter {
+		return resp, nil
+	}
+	t.holdHost(req.URL.Host, time.Now().Add(wait))
+
+	// Retry once, if the body can be replayed and the wait fits in the
+	// request's deadline.
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) <= wait {
+		return resp, nil
+	}
+	resp.Body.Close()
+	if err := t.waitForHost(req.Context(), req.URL.Host); err != nil {
+		return nil, err
+	}
+	return base.RoundTrip(retry)
+}
+
+// waitForHost sleeps until host's Retry-After has passed. It fails when the
+// context would run out first, rather than sending a request the host
+// asked not to get yet.
+func (t *PoliteTransport) waitForHost(ctx context.Context, host string) error {
+	t.mu.Lock()
+	until := t.retryAfter[host]
+	t.mu.Unlock()
+
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+		return fmt.Errorf("%s is rate limiting requests (Retry-After %s)", host, wait.Round(time.Second))
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *PoliteTransport) holdHost(host string, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.retryAfter == nil {
+		t.retryAfter = make(map[string]time.Time)
+	}
+	if until.After(t.retryAfter[host]) {
+		t.retryAfter[host] = until
+	}
+}
+
+// parseRetryAfter reads a Retry-After value, either delay-seconds or an
+// HTTP date (RFC 9110 §10.2.3), as a wait from now.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,119 @@
+package netutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoliteUserAgent(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.UserAgent()
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("User-Agent", "something-else")
+	resp, err := Polite(srv.Client(), "preflight/1.2.3; +https://preflight.sh").Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got != "preflight/1.2.3; +https://preflight.sh" {
+		t.Errorf("User-Agent = %q", got)
+	}
+	if req.Header.Get("User-Agent") != "something-else" {
+		t.Error("Polite modified the caller's request")
+	}
+
+	// An empty User-Agent leaves Go's default.
+	resp, err = Polite(srv.Client(), "").Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if !strings.HasPrefix(got, "Go-http-client/") {
+		t.Errorf("User-Agent = %q, want Go's default", got)
+	}
+}
+
+func TestPoliteRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	client := Polite(srv.Client(), "preflight/test")
+	start := time.Now()
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || hits.Load() != 2 {
+		t.Errorf("status %d after %d requests, want 200 after a retry", resp.StatusCode, hits.Load())
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After", waited)
+	}
+}
+
+func TestPoliteRetryAfterPastDeadline(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := Polite(srv.Client(), "preflight/test")
+	client.Timeout = 2 * time.Second
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status %d, want the 429 passed through", resp.StatusCode)
+	}
+
+	// The host asked for 20s; the next request isn't sent before then.
+	if _, err := client.Get(srv.URL); err == nil || !strings.Contains(err.Error(), "rate limiting") {
+		t.Errorf("second request err = %v, want a rate-limit error", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("server got %d requests, want 1", hits.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{"Thu, 01 Jan 2026 12:00:30 GMT", 30 * time.Second, true},
+		{"Thu, 01 Jan 2026 11:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := parseRetryAfter(c.in, now)
+		if got != c.want || ok != c.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
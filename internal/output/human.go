@@ -50,9 +50,12 @@ func shouldUseColor() bool {
 
 type HumanOutputter struct {
 	Verbose bool
+	// Incomplete lists why the scan stopped short (deadline, file limit);
+	// empty for a complete scan.
+	Incomplete []string
 }
 
-func (h HumanOutputter) Output(projectName string, results []checks.CheckResult) {
+func (h HumanOutputter) Output(projectName string, results []checks.CheckResult) error {
 	// Header
 	fmt.Println()
 	fmt.Printf("%s%s ✈  Preflight Scan Results%s\n", colorBold, colorCyan, colorReset)
@@ -91,36 +94,53 @@ func (h HumanOutputter) Output(projectName string, results []checks.CheckResult)
 		"DEBUG":     "🐞",
 		"PERF":      "⚡",
 		"LEGAL":     "⚖️ ",
+		"POLISH":    "✨",
+		"DEPLOY":    "🚀",
 	}
 
 	// Map check IDs to display categories
 	categoryMap := map[string]string{
-		"envParity":          "ENV",
-		"healthEndpoint":     "HEALTH",
-		"seoMeta":            "SEO",
-		"ogTwitter":          "SOCIAL",
-		"securityHeaders":    "SECURITY",
-		"ssl":                "SSL",
-		"secrets":            "SECRETS",
-		"favicon":            "ICONS",
-		"robotsTxt":          "FILES",
-		"sitemap":            "FILES",
-		"llmsTxt":            "FILES",
-		"adsTxt":             "FILES",
-		"humansTxt":          "FILES",
-		"license":            "LICENSE",
-		"vulnerability":      "DEPS",
-		"indexNow":           "INDEXNOW",
-		"canonical":          "SEO",
-		"viewport":           "MOBILE",
-		"lang":               "LANG",
-		"error_pages":        "PAGES",
-		"debug_statements":   "DEBUG",
-		"structured_data":    "SEO",
-		"image_optimization": "PERF",
-		"email_auth":         "EMAIL",
-		"www_redirect":       "INFRA",
-		"legal_pages":        "LEGAL",
+		"envParity":           "ENV",
+		"platform_env":        "ENV",
+		"secrets_manager":     "SECRETS",
+		"healthEndpoint":      "HEALTH",
+		"seoMeta":             "SEO",
+		"ogTwitter":           "SOCIAL",
+		"securityHeaders":     "SECURITY",
+		"ssl":                 "SSL",
+		"secrets":             "SECRETS",
+		"favicon":             "ICONS",
+		"robotsTxt":           "FILES",
+		"sitemap":             "FILES",
+		"llmsTxt":             "FILES",
+		"adsTxt":              "FILES",
+		"humansTxt":           "FILES",
+		"license":             "LICENSE",
+		"vulnerability":       "DEPS",
+		"indexNow":            "INDEXNOW",
+		"canonical":           "SEO",
+		"viewport":            "MOBILE",
+		"lang":                "LANG",
+		"error_pages":         "PAGES",
+		"debug_statements":    "DEBUG",
+		"deprecated_services": "DEPS",
+		"dead_code":           "DEBUG",
+		"build_freshness":     "DEPLOY",
+		"structured_data":     "SEO",
+		"image_optimization":  "PERF",
+		"email_auth":          "EMAIL",
+		"www_redirect":        "INFRA",
+		"ipv6":                "INFRA",
+		"multi_region":        "INFRA",
+		"legal_pages":         "LEGAL",
+		"legal_placeholders":  "LEGAL",
+		"legal_freshness":     "LEGAL",
+		"consent_mode":        "LEGAL",
+		"consent_banner":      "LEGAL",
+		"do_not_sell":         "LEGAL",
+		"impressum":           "LEGAL",
+		"age_gate":            "LEGAL",
+		"email_obfuscation":   "POLISH",
 	}
 
 	// Service check IDs - these will be grouped separately
@@ -283,15 +303,26 @@ func (h HumanOutputter) Output(projectName string, results []checks.CheckResult)
 	fmt.Println()
 	fmt.Println()
 
+	if len(h.Incomplete) > 0 {
+		fmt.Printf("  %s%s⚠ Incomplete scan: these results are partial%s\n", colorBold, colorYellow, colorReset)
+		for _, reason := range h.Incomplete {
+			fmt.Printf("  %s   └─ %s%s\n", colorGray, reason, colorReset)
+		}
+		fmt.Println()
+	}
+
 	// Final verdict
 	if summary.Fail > 0 {
 		fmt.Printf("  %s%s✗ Not ready for launch%s\n", colorBold, colorRed, colorReset)
 	} else if summary.Warn > 0 {
 		fmt.Printf("  %s%s⚠ Review warnings before launch%s\n", colorBold, colorYellow, colorReset)
+	} else if len(h.Incomplete) > 0 {
+		fmt.Printf("  %s%s⚠ No issues found, but not every check ran%s\n", colorBold, colorYellow, colorReset)
 	} else {
 		fmt.Printf("  %s%s✓ Ready for launch!%s\n", colorBold, colorGreen, colorReset)
 	}
 	fmt.Println()
+	return nil
 }
 
 // hasUsefulPassedMessage returns true if the message contains info worth showing
@@ -1,19 +1,39 @@
 package output
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"os"
 
+	"github.com/preflightsh/preflight/internal/attest"
 	"github.com/preflightsh/preflight/internal/checks"
 )
 
-type JSONOutputter struct{}
+type JSONOutputter struct {
+	// SignKey, when set, signs the report (see SignedPayload) and embeds
+	// the signature.
+	SignKey ed25519.PrivateKey
+	// Incomplete lists why the scan stopped short (deadline, file limit);
+	// empty for a complete scan.
+	Incomplete []string
+}
 
 type JSONOutput struct {
 	Project string            `json:"project"`
 	Summary Summary           `json:"summary"`
 	Checks  []JSONCheckResult `json:"checks"`
+	// Incomplete marks partial results from a scan that hit a limit.
+	Incomplete        bool              `json:"incomplete,omitempty"`
+	IncompleteReasons []string          `json:"incomplete_reasons,omitempty"`
+	Signature         *attest.Signature `json:"signature,omitempty"`
+}
+
+// SignedPayload returns the bytes a report's signature covers: the compact
+// JSON encoding of the report without its signature.
+func (o JSONOutput) SignedPayload() ([]byte, error) {
+	o.Signature = nil
+	return json.Marshal(o)
 }
 
 type JSONCheckResult struct {
@@ -25,11 +45,16 @@ type JSONCheckResult struct {
 	Suggestions []string `json:"suggestions,omitempty"`
 }
 
-func (j JSONOutputter) Output(projectName string, results []checks.CheckResult) {
+// Output writes the report to stdout. With SignKey set, a report that
+// can't be signed is not written at all, so it can't pass for a signed one.
+func (j JSONOutputter) Output(projectName string, results []checks.CheckResult) error {
 	output := JSONOutput{
 		Project: projectName,
 		Summary: CalculateSummary(results),
 		Checks:  make([]JSONCheckResult, len(results)),
+
+		Incomplete:        len(j.Incomplete) > 0,
+		IncompleteReasons: j.Incomplete,
 	}
 
 	for i, r := range results {
@@ -43,9 +68,19 @@ func (j JSONOutputter) Output(projectName string, results []checks.CheckResult)
 		}
 	}
 
+	if j.SignKey != nil {
+		payload, err := output.SignedPayload()
+		if err != nil {
+			return fmt.Errorf("signing report: %w", err)
+		}
+		sig := attest.Sign(j.SignKey, payload)
+		output.Signature = &sig
+	}
+
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(output); err != nil {
-		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		return fmt.Errorf("encoding JSON: %w", err)
 	}
+	return nil
 }
@@ -3,7 +3,7 @@ package output
 import "github.com/preflightsh/preflight/internal/checks"
 
 type Outputter interface {
-	Output(projectName string, results []checks.CheckResult)
+	Output(projectName string, results []checks.CheckResult) error
 }
 
 type Summary struct {
@@ -0,0 +1,91 @@
+// Package wellknown renders the site files `preflight init` can generate:
+// security.txt (RFC 9116), humans.txt (humanstxt.org) and llms.txt
+// (llmstxt.org).
+package wellknown
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SecurityContact turns an answer into a security.txt Contact value: a
+// mailto:, https: or tel: URI (RFC 9116 §2.5.3), with a bare email address
+// becoming mailto:. It reports false for anything else.
+func SecurityContact(answer string) (string, bool) {
+	switch {
+	case strings.HasPrefix(answer, "mailto:"), strings.HasPrefix(answer, "https://"), strings.HasPrefix(answer, "tel:"):
+		return answer, true
+	case strings.Contains(answer, "@") && !strings.Contains(answer, "/"):
+		return "mailto:" + answer, true
+	}
+	return "", false
+}
+
+// SecurityTxt formats a security.txt. Expires is required; Canonical is
+// added when the production URL is known.
+func SecurityTxt(contact string, expires time.Time, productionURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Contact: %s\n", contact)
+	fmt.Fprintf(&b, "Expires: %s\n", expires.UTC().Format(time.RFC3339))
+	b.WriteString("Preferred-Languages: en\n")
+	if productionURL != "" {
+		fmt.Fprintf(&b, "Canonical: %s/.well-known/security.txt\n", strings.TrimSuffix(productionURL, "/"))
+	}
+	return b.String()
+}
+
+// TeamMember is one humans.txt credit.
+type TeamMember struct {
+	Name string
+	Role string
+}
+
+// ParseTeamCredits splits "Name - Role, Name - Role" into credits. A
+// member without a role is credited as a contributor.
+func ParseTeamCredits(answer string) []TeamMember {
+	var team []TeamMember
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		m := TeamMember{Name: part, Role: "Contributor"}
+		if name, role, ok := strings.Cut(part, " - "); ok {
+			m.Name, m.Role = strings.TrimSpace(name), strings.TrimSpace(role)
+		}
+		team = append(team, m)
+	}
+	return team
+}
+
+// HumansTxt formats a humans.txt in the humanstxt.org layout. An empty or
+// "unknown" software name is left out.
+func HumansTxt(team []TeamMember, software string, updated time.Time) string {
+	var b strings.Builder
+	b.WriteString("/* TEAM */\n")
+	for _, m := range team {
+		fmt.Fprintf(&b, "\t%s: %s\n", m.Role, m.Name)
+	}
+	b.WriteString("\n/* SITE */\n")
+	fmt.Fprintf(&b, "\tLast update: %s\n", updated.Format("2006/01/02"))
+	b.WriteString("\tLanguage: English\n")
+	if software != "" && software != "unknown" {
+		fmt.Fprintf(&b, "\tSoftware: %s\n", software)
+	}
+	return b.String()
+}
+
+// LLMsTxt formats an llms.txt: an H1 with the site name, a blockquote
+// summary, then a section of links.
+func LLMsTxt(projectName, summary, productionURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", projectName)
+	fmt.Fprintf(&b, "> %s\n", summary)
+	if productionURL != "" {
+		base := strings.TrimSuffix(productionURL, "/")
+		b.WriteString("\n## Pages\n\n")
+		fmt.Fprintf(&b, "- [Home](%s/): %s\n", base, summary)
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package wellknown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecurityContact(t *testing.T) {
+	cases := []struct {
+		answer string
+		want   string
+		ok     bool
+	}{
+		{"security@example.com", "mailto:security@example.com", true},
+		{"mailto:security@example.com", "mailto:security@example.com", true},
+		{"https://example.com/security", "https://example.com/security", true},
+		{"tel:+1-201-555-0123", "tel:+1-201-555-0123", true},
+		{"http://example.com/security", "", false},
+		{"example.com/security", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := SecurityContact(tc.answer)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("SecurityContact(%q) = %q, %v, want %q, %v", tc.answer, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestSecurityTxt(t *testing.T) {
+	expires := time.Date(2027, 1, 2, 3, 4, 5, 0, time.UTC)
+	got := SecurityTxt("mailto:security@example.com", expires, "https://example.com/")
+	want := "Contact: mailto:security@example.com\n" +
+		"Expires: 2027-01-02T03:04:05Z\n" +
+		"Preferred-Languages: en\n" +
+		"Canonical: https://example.com/.well-known/security.txt\n"
+	if got != want {
+		t.Errorf("SecurityTxt =\n%s\nwant\n%s", got, want)
+	}
+	if got := SecurityTxt("mailto:a@b.c", expires, ""); strings.Contains(got, "Canonical") {
+		t.Errorf("Canonical without a production URL:\n%s", got)
+	}
+}
+
+func TestParseTeamCredits(t *testing.T) {
+	got := ParseTeamCredits(" Jane Doe - Developer, Sam Lee ,, Ana - Design - Lead")
+	want := []TeamMember{
+		{Name: "Jane Doe", Role: "Developer"},
+		{Name: "Sam Lee", Role: "Contributor"},
+		{Name: "Ana", Role: "Design - Lead"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseTeamCredits = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseTeamCredits[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if got := ParseTeamCredits(" , "); len(got) != 0 {
+		t.Errorf("blank answer gave %v", got)
+	}
+}
+
+func TestHumansTxt(t *testing.T) {
+	updated := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	team := []TeamMember{{Name: "Jane Doe", Role: "Developer"}}
+	got := HumansTxt(team, "Hugo", updated)
+	want := "/* TEAM */\n\tDeveloper: Jane Doe\n\n/* SITE */\n\tLast update: 2026/10/16\n\tLanguage: English\n\tSoftware: Hugo\n"
+	if got != want {
+		t.Errorf("HumansTxt =\n%s\nwant\n%s", got, want)
+	}
+	for _, software := range []string{"", "unknown"} {
+		if got := HumansTxt(team, software, updated); strings.Contains(got, "Software:") {
+			t.Errorf("software %q should be left out:\n%s", software, got)
+		}
+	}
+}
+
+func TestLLMsTxt(t *testing.T) {
+	got := LLMsTxt("Acme", "Widgets for everyone.", "https://acme.io/")
+	want := "# Acme\n\n> Widgets for everyone.\n\n## Pages\n\n- [Home](https://acme.io/): Widgets for everyone.\n"
+	if got != want {
+		t.Errorf("LLMsTxt =\n%s\nwant\n%s", got, want)
+	}
+	if got := LLMsTxt("Acme", "Widgets.", ""); got != "# Acme\n\n> Widgets.\n" {
+		t.Errorf("LLMsTxt without URL = %q", got)
+	}
+}